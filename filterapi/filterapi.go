@@ -0,0 +1,180 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package filterapi defines the configuration schema shared between the
+// AI Gateway control plane and the external processor (extproc) filter.
+package filterapi
+
+import (
+	"fmt"
+	"time"
+)
+
+// APISchemaName is the name of an API schema supported by the AI Gateway,
+// either as the "front door" schema exposed to clients or as the schema
+// spoken by an upstream backend.
+type APISchemaName string
+
+const (
+	// APISchemaOpenAI is the OpenAI API schema.
+	APISchemaOpenAI APISchemaName = "OpenAI"
+	// APISchemaAWSBedrock is the AWS Bedrock Runtime API schema.
+	APISchemaAWSBedrock APISchemaName = "AWSBedrock"
+	// APISchemaAzureOpenAI is the Azure OpenAI API schema.
+	APISchemaAzureOpenAI APISchemaName = "AzureOpenAI"
+	// APISchemaCohere is the Cohere Chat API schema.
+	APISchemaCohere APISchemaName = "Cohere"
+)
+
+// VersionedAPISchema is a schema together with an optional version, e.g. the
+// Azure OpenAI API version or a Bedrock API revision.
+type VersionedAPISchema struct {
+	// Name is one of the APISchema* constants.
+	Name APISchemaName `json:"name"`
+	// Version is the backend-specific API version. It is ignored by schemas
+	// that are not versioned.
+	Version string `json:"version,omitempty"`
+}
+
+// String implements [fmt.Stringer].
+func (v VersionedAPISchema) String() string {
+	if v.Version == "" {
+		return string(v.Name)
+	}
+	return string(v.Name) + "/" + v.Version
+}
+
+// Backend is a single upstream destination that a route can select.
+type Backend struct {
+	// Name is the unique name of the backend within the Gateway configuration.
+	Name string `json:"name"`
+	// Schema is the API schema spoken by this backend.
+	Schema VersionedAPISchema `json:"schema"`
+	// DynamicLoadBalancing, when non-nil, indicates that the endpoint for this
+	// backend is resolved dynamically rather than via static Envoy cluster
+	// routing.
+	DynamicLoadBalancing *DynamicLoadBalancing `json:"dynamicLoadBalancing,omitempty"`
+	// AzureOpenAI configures deployment-per-model routing. It is set only when
+	// Schema.Name is APISchemaAzureOpenAI.
+	AzureOpenAI *AzureOpenAIBackend `json:"azureOpenAI,omitempty"`
+}
+
+// AzureOpenAIBackend configures an Azure OpenAI backend that hosts one or
+// more deployments, each serving a single model under Azure's
+// deployment-scoped routing scheme (e.g.
+// /openai/deployments/{deployment-id}/chat/completions).
+type AzureOpenAIBackend struct {
+	// Deployments maps an OpenAI model name, as requested by the client, to
+	// the Azure deployment that serves it. Every model reachable through a
+	// route pointing at this backend must have an entry here; ValidateModels
+	// checks this.
+	Deployments map[string]AzureOpenAIDeployment `json:"deployments"`
+}
+
+// ValidateModels reports an error naming the first model in models that has
+// no deployment mapping in this backend. It is exported for the control
+// plane that builds this configuration (see the package doc) to call at
+// config-load time; nothing in the extproc filter itself calls it, since a
+// missing mapping is already reported as a per-request error by
+// [AzureOpenAIBackend]'s translator.
+func (b AzureOpenAIBackend) ValidateModels(models []string) error {
+	for _, model := range models {
+		if _, ok := b.Deployments[model]; !ok {
+			return fmt.Errorf("model %q has no Azure OpenAI deployment mapping", model)
+		}
+	}
+	return nil
+}
+
+// AzureOpenAIDeployment identifies a single Azure OpenAI deployment and the
+// API version to address it with.
+type AzureOpenAIDeployment struct {
+	// ID is the Azure deployment name, e.g. "gpt-4o-prod".
+	ID string `json:"id"`
+	// APIVersion is the Azure OpenAI API version used when the alias passed
+	// to ResolveAPIVersion is empty or not one of APIVersionAliases, e.g.
+	// "2024-06-01".
+	APIVersion string `json:"apiVersion"`
+	// APIVersionAliases maps additional recognized API-version aliases (e.g.
+	// "preview", "2024-02-01") to the concrete API version to send, so a
+	// single deployment can serve more than one API version, such as a mix
+	// of GA and preview models.
+	APIVersionAliases map[string]string `json:"apiVersionAliases,omitempty"`
+}
+
+// ResolveAPIVersion returns the concrete Azure OpenAI API version to request
+// for this deployment given the alias asked for (typically the Version of
+// the backend's VersionedAPISchema). An empty or unrecognized alias falls
+// back to APIVersion.
+func (d AzureOpenAIDeployment) ResolveAPIVersion(alias string) string {
+	if alias != "" {
+		if v, ok := d.APIVersionAliases[alias]; ok {
+			return v
+		}
+	}
+	return d.APIVersion
+}
+
+// DynamicLoadBalancing configures a backend whose concrete endpoint is
+// selected at request time, e.g. to load balance across multiple API keys or
+// regions for the same logical backend.
+//
+// Failover across endpoints isn't implemented: Envoy's HTTP filter chain,
+// ext_proc included, runs once per downstream request, so nothing in this
+// process is re-invoked when Envoy's router filter retries a request against
+// a different upstream host. Automatic failover would require a retry
+// policy configured on the Envoy route itself (outside this package's
+// scope) and, on an ext_proc-visible retry, the ability to exclude the
+// previously-selected endpoint — neither of which exists yet.
+type DynamicLoadBalancing struct {
+	// Name identifies this load balancing configuration and is used to look up
+	// the corresponding runtime implementation.
+	Name string `json:"name"`
+}
+
+// Route groups the backends available for a single routing rule together
+// with any per-route overrides of Gateway-wide defaults.
+type Route struct {
+	// Name is the unique name of the route within the Gateway configuration.
+	Name string `json:"name"`
+	// Backends lists the upstream backends this route can select among.
+	Backends []Backend `json:"backends"`
+	// Timeout overrides the Gateway's default per-request deadline for
+	// requests matched to this route. A nil Timeout means the default applies.
+	Timeout *time.Duration `json:"timeout,omitempty"`
+}
+
+// LLMRequestCostType is the kind of cost value attached to a request cost
+// entry.
+type LLMRequestCostType string
+
+const (
+	// LLMRequestCostTypeInputToken uses the number of input (prompt) tokens as the cost.
+	LLMRequestCostTypeInputToken LLMRequestCostType = "InputToken"
+	// LLMRequestCostTypeOutputToken uses the number of output (completion) tokens as the cost.
+	LLMRequestCostTypeOutputToken LLMRequestCostType = "OutputToken"
+	// LLMRequestCostTypeTotalToken uses the total number of tokens as the cost.
+	LLMRequestCostTypeTotalToken LLMRequestCostType = "TotalToken"
+	// LLMRequestCostTypeCEL evaluates a CEL expression to compute the cost.
+	LLMRequestCostTypeCEL LLMRequestCostType = "CEL"
+	// LLMRequestCostTypeImages uses the number of images generated as the cost.
+	LLMRequestCostTypeImages LLMRequestCostType = "Images"
+	// LLMRequestCostTypeAudioSeconds uses the number of seconds of audio
+	// processed or synthesized as the cost.
+	LLMRequestCostTypeAudioSeconds LLMRequestCostType = "AudioSeconds"
+)
+
+// LLMRequestCost configures a single value to be emitted as dynamic metadata
+// so that it can be consumed by Envoy's rate limit filters.
+type LLMRequestCost struct {
+	// Type selects how the cost is computed.
+	Type LLMRequestCostType `json:"type"`
+	// MetadataKey is the key under which the computed cost is stored in the
+	// dynamic metadata namespace.
+	MetadataKey string `json:"metadataKey"`
+	// CELExpression is the CEL expression to evaluate when Type is
+	// LLMRequestCostTypeCEL.
+	CELExpression string `json:"celExpression,omitempty"`
+}
@@ -0,0 +1,50 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package filterapi
+
+import "testing"
+
+func TestAzureOpenAIDeployment_ResolveAPIVersion(t *testing.T) {
+	d := AzureOpenAIDeployment{
+		ID:         "gpt-4o-prod",
+		APIVersion: "2024-06-01",
+		APIVersionAliases: map[string]string{
+			"preview": "2024-09-01-preview",
+		},
+	}
+
+	tests := []struct {
+		alias string
+		want  string
+	}{
+		{alias: "", want: "2024-06-01"},
+		{alias: "preview", want: "2024-09-01-preview"},
+		{alias: "unknown-alias", want: "2024-06-01"},
+	}
+	for _, tt := range tests {
+		if got := d.ResolveAPIVersion(tt.alias); got != tt.want {
+			t.Errorf("ResolveAPIVersion(%q) = %q, want %q", tt.alias, got, tt.want)
+		}
+	}
+}
+
+func TestAzureOpenAIBackend_ValidateModels(t *testing.T) {
+	b := AzureOpenAIBackend{
+		Deployments: map[string]AzureOpenAIDeployment{
+			"gpt-4o": {ID: "gpt-4o-prod", APIVersion: "2024-06-01"},
+		},
+	}
+
+	if err := b.ValidateModels([]string{"gpt-4o"}); err != nil {
+		t.Errorf("ValidateModels with a mapped model: unexpected error: %v", err)
+	}
+	if err := b.ValidateModels(nil); err != nil {
+		t.Errorf("ValidateModels with no models: unexpected error: %v", err)
+	}
+	if err := b.ValidateModels([]string{"gpt-4o", "gpt-4o-mini"}); err == nil {
+		t.Error("ValidateModels with an unmapped model: expected an error, got nil")
+	}
+}
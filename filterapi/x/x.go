@@ -0,0 +1,71 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package x holds extension points of the AI Gateway filter that are
+// exposed for use by custom builds of the external processor, such as
+// metrics collectors tailored to a particular deployment.
+package x
+
+import (
+	"context"
+	"errors"
+
+	"github.com/envoyproxy/ai-gateway/filterapi"
+)
+
+// ErrNoMatchingRule is returned by the extproc filter's router when no
+// routing rule matches the incoming request.
+var ErrNoMatchingRule = errors.New("no matching rule found")
+
+// GenAIMetrics is embedded by every per-surface metrics interface below and
+// tracks the parts of a request's lifecycle that are common to every OpenAI
+// API surface the Gateway exposes (chat completions, embeddings, images,
+// audio transcription/speech).
+type GenAIMetrics interface {
+	// StartRequest is called when the request headers have been received.
+	StartRequest(requestHeaders map[string]string)
+	// SetModel records the model requested by the client.
+	SetModel(model string)
+	// SetBackend records the backend that was selected to serve the request.
+	SetBackend(backend *filterapi.Backend)
+	// RecordRequestCompletion records that the request has completed, successfully or not.
+	RecordRequestCompletion(ctx context.Context, success bool)
+}
+
+// ChatCompletionMetrics tracks metrics for a single chat completion request
+// from the time it arrives until the response (or an error) completes.
+type ChatCompletionMetrics interface {
+	GenAIMetrics
+	// RecordTokenUsage records the token usage reported by a single response
+	// body event, as an increment over the events before it.
+	RecordTokenUsage(ctx context.Context, inputTokens, outputTokens, totalTokens uint32)
+	// RecordTokenLatency records the latency between two streamed output token events.
+	RecordTokenLatency(ctx context.Context, outputTokens uint32)
+}
+
+// EmbeddingsMetrics tracks metrics for a single embeddings request.
+type EmbeddingsMetrics interface {
+	GenAIMetrics
+	// RecordTokenUsage records the number of input tokens embedded.
+	RecordTokenUsage(ctx context.Context, inputTokens uint32)
+	// RecordEmbeddingsCount records the number of embedding vectors returned.
+	RecordEmbeddingsCount(ctx context.Context, count uint32)
+}
+
+// ImagesMetrics tracks metrics for a single image generation request.
+type ImagesMetrics interface {
+	GenAIMetrics
+	// RecordImageCount records the number of images generated.
+	RecordImageCount(ctx context.Context, count uint32)
+}
+
+// AudioMetrics tracks metrics for a single audio transcription or
+// text-to-speech request.
+type AudioMetrics interface {
+	GenAIMetrics
+	// RecordAudioSeconds records the duration, in seconds, of the audio
+	// processed or synthesized.
+	RecordAudioSeconds(ctx context.Context, seconds float64)
+}
@@ -0,0 +1,36 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package openai
+
+// AudioTranscriptionRequest corresponds to the (multipart/form-data) request
+// of the OpenAI "Create transcription" API. The audio file itself is kept in
+// the raw multipart body and is not re-parsed here; only the fields the
+// Gateway needs to route and account for the request are extracted.
+//
+// https://platform.openai.com/docs/api-reference/audio/createTranscription
+type AudioTranscriptionRequest struct {
+	// Model is the ID of the model to use, e.g. "whisper-1".
+	Model string `json:"model"`
+	// DurationSeconds is the duration of the submitted audio, used for
+	// cost accounting. It is estimated from the size of the uploaded file,
+	// since OpenAI's API does not require the client to declare it.
+	DurationSeconds float64 `json:"-"`
+}
+
+// AudioSpeechRequest corresponds to the request body of the OpenAI
+// "Create speech" API.
+//
+// https://platform.openai.com/docs/api-reference/audio/createSpeech
+type AudioSpeechRequest struct {
+	// Model is the ID of the model to use, e.g. "tts-1".
+	Model string `json:"model"`
+	// Input is the text to synthesize.
+	Input string `json:"input"`
+	// Voice is the voice to use for the synthesized audio.
+	Voice string `json:"voice"`
+	// ResponseFormat is the audio encoding of the response, e.g. "mp3".
+	ResponseFormat string `json:"response_format,omitempty"`
+}
@@ -0,0 +1,54 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package openai contains the request/response types for the subset of the
+// OpenAI API that the AI Gateway understands, shared by every translator.
+package openai
+
+// ChatCompletionRequest corresponds to the request body of the OpenAI
+// "Create chat completion" API.
+//
+// https://platform.openai.com/docs/api-reference/chat/create
+type ChatCompletionRequest struct {
+	// Model is the ID of the model to use.
+	Model string `json:"model"`
+	// Messages is the list of messages comprising the conversation so far.
+	Messages []ChatCompletionMessage `json:"messages"`
+	// Stream indicates whether the server should send partial message deltas
+	// as they become available via server-sent events.
+	Stream bool `json:"stream,omitempty"`
+	// Temperature is the sampling temperature to use.
+	Temperature *float64 `json:"temperature,omitempty"`
+	// Tools is the list of tools the model may call.
+	Tools []Tool `json:"tools,omitempty"`
+}
+
+// ChatCompletionMessage is a single message in a ChatCompletionRequest.
+type ChatCompletionMessage struct {
+	// Role is one of "system", "user", "assistant", or "tool".
+	Role string `json:"role"`
+	// Content is the textual content of the message.
+	Content string `json:"content"`
+	// ToolCallID is set when Role is "tool" to identify the call being answered.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+// Tool is a tool that the model may call, currently always a function.
+type Tool struct {
+	// Type is always "function".
+	Type string `json:"type"`
+	// Function describes the callable function.
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction describes a callable function exposed to the model.
+type ToolFunction struct {
+	// Name is the function name.
+	Name string `json:"name"`
+	// Description explains what the function does.
+	Description string `json:"description,omitempty"`
+	// Parameters is the JSON Schema of the function parameters.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
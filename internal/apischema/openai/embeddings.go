@@ -0,0 +1,45 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package openai
+
+import "encoding/json"
+
+// EmbeddingRequest corresponds to the request body of the OpenAI
+// "Create embeddings" API.
+//
+// https://platform.openai.com/docs/api-reference/embeddings/create
+type EmbeddingRequest struct {
+	// Model is the ID of the model to use.
+	Model string `json:"model"`
+	// Input is the text (or texts) to embed.
+	Input EmbeddingInput `json:"input"`
+	// EncodingFormat is either "float" or "base64".
+	EncodingFormat string `json:"encoding_format,omitempty"`
+}
+
+// EmbeddingInput holds either a single input string or a batch of them.
+type EmbeddingInput struct {
+	Single string
+	Batch  []string
+}
+
+// MarshalJSON implements [json.Marshaler].
+func (e EmbeddingInput) MarshalJSON() ([]byte, error) {
+	if e.Batch != nil {
+		return json.Marshal(e.Batch)
+	}
+	return json.Marshal(e.Single)
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (e *EmbeddingInput) UnmarshalJSON(data []byte) error {
+	var batch []string
+	if err := json.Unmarshal(data, &batch); err == nil {
+		e.Batch = batch
+		return nil
+	}
+	return json.Unmarshal(data, &e.Single)
+}
@@ -0,0 +1,21 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package openai
+
+// ImageGenerationRequest corresponds to the request body of the OpenAI
+// "Create image" API.
+//
+// https://platform.openai.com/docs/api-reference/images/create
+type ImageGenerationRequest struct {
+	// Model is the ID of the model to use.
+	Model string `json:"model"`
+	// Prompt is a text description of the desired image(s).
+	Prompt string `json:"prompt"`
+	// N is the number of images to generate.
+	N int `json:"n,omitempty"`
+	// Size is the size of the generated images, e.g. "1024x1024".
+	Size string `json:"size,omitempty"`
+}
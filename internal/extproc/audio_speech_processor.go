@@ -0,0 +1,218 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package extproc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+
+	"github.com/envoyproxy/ai-gateway/filterapi"
+	"github.com/envoyproxy/ai-gateway/filterapi/x"
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+	"github.com/envoyproxy/ai-gateway/internal/extproc/translator"
+)
+
+// AudioSpeechProcessorFactory returns a factory method to instantiate the
+// text-to-speech processor.
+func AudioSpeechProcessorFactory(am x.AudioMetrics) ProcessorFactory {
+	return func(config *processorConfig, requestHeaders map[string]string, logger *slog.Logger) (Processor, error) {
+		if config.schema.Name != filterapi.APISchemaOpenAI {
+			return nil, fmt.Errorf("unsupported API schema: %s", config.schema.Name)
+		}
+		return &audioSpeechProcessor{
+			config:         config,
+			requestHeaders: requestHeaders,
+			logger:         logger,
+			metrics:        am,
+		}, nil
+	}
+}
+
+// audioSpeechProcessor handles the processing of the request and response
+// messages for a single text-to-speech stream.
+type audioSpeechProcessor struct {
+	logger           *slog.Logger
+	config           *processorConfig
+	requestHeaders   map[string]string
+	responseHeaders  map[string]string
+	responseEncoding string
+	translator       translator.OpenAIAudioSpeechTranslator
+	metrics          x.AudioMetrics
+}
+
+func (a *audioSpeechProcessor) selectTranslator(out filterapi.VersionedAPISchema) error {
+	if a.translator != nil {
+		return nil
+	}
+	switch out.Name {
+	case filterapi.APISchemaOpenAI:
+		a.translator = translator.NewAudioSpeechOpenAIToOpenAITranslator()
+	default:
+		return fmt.Errorf("unsupported API schema: backend=%s", out)
+	}
+	return nil
+}
+
+// ProcessRequestHeaders implements [Processor.ProcessRequestHeaders].
+func (a *audioSpeechProcessor) ProcessRequestHeaders(_ context.Context, _ *corev3.HeaderMap) (*extprocv3.ProcessingResponse, error) {
+	a.metrics.StartRequest(a.requestHeaders)
+	return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestHeaders{
+		RequestHeaders: &extprocv3.HeadersResponse{},
+	}}, nil
+}
+
+// ProcessRequestBody implements [Processor.ProcessRequestBody].
+func (a *audioSpeechProcessor) ProcessRequestBody(ctx context.Context, rawBody *extprocv3.HttpBody) (res *extprocv3.ProcessingResponse, err error) {
+	defer func() {
+		if err != nil {
+			a.metrics.RecordRequestCompletion(ctx, false)
+		}
+	}()
+	var body openai.AudioSpeechRequest
+	if err = json.Unmarshal(rawBody.Body, &body); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal body: %w", err)
+	}
+	a.logger.Info("processing audio speech request body", "path", a.requestHeaders[":path"], "model", body.Model)
+
+	a.metrics.SetModel(body.Model)
+	a.requestHeaders[a.config.modelNameHeaderKey] = body.Model
+	b, _, err := a.config.router.Calculate(a.requestHeaders)
+	if err != nil {
+		if errors.Is(err, x.ErrNoMatchingRule) {
+			a.metrics.RecordRequestCompletion(ctx, false)
+			return &extprocv3.ProcessingResponse{
+				Response: &extprocv3.ProcessingResponse_ImmediateResponse{
+					ImmediateResponse: &extprocv3.ImmediateResponse{
+						Status: &typev3.HttpStatus{Code: typev3.StatusCode_NotFound},
+						Body:   []byte(err.Error()),
+					},
+				},
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to calculate route: %w", err)
+	}
+
+	a.logger.Info("selected backend", "backend", b.Name, "schema", b.Schema)
+	a.metrics.SetBackend(b)
+
+	if err = a.selectTranslator(b.Schema); err != nil {
+		return nil, fmt.Errorf("failed to select translator: %w", err)
+	}
+
+	headerMutation, bodyMutation, err := a.translator.RequestBody(&body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform request: %w", err)
+	}
+	if headerMutation == nil {
+		headerMutation = &extprocv3.HeaderMutation{}
+	}
+	headerMutation.SetHeaders = append(headerMutation.SetHeaders, &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{Key: a.config.modelNameHeaderKey, RawValue: []byte(body.Model)},
+	}, &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{Key: a.config.selectedBackendHeaderKey, RawValue: []byte(b.Name)},
+	})
+	if authHandler, ok := a.config.backendAuthHandlers[b.Name]; ok {
+		if err = authHandler.Do(ctx, a.requestHeaders, headerMutation, bodyMutation); err != nil {
+			return nil, fmt.Errorf("failed to do auth request: %w", err)
+		}
+	}
+
+	return &extprocv3.ProcessingResponse{
+		Response: &extprocv3.ProcessingResponse_RequestBody{
+			RequestBody: &extprocv3.BodyResponse{
+				Response: &extprocv3.CommonResponse{
+					HeaderMutation:  headerMutation,
+					BodyMutation:    bodyMutation,
+					ClearRouteCache: true,
+				},
+			},
+		},
+	}, nil
+}
+
+// ProcessResponseHeaders implements [Processor.ProcessResponseHeaders].
+func (a *audioSpeechProcessor) ProcessResponseHeaders(ctx context.Context, headers *corev3.HeaderMap) (res *extprocv3.ProcessingResponse, err error) {
+	defer func() {
+		if err != nil {
+			a.metrics.RecordRequestCompletion(ctx, false)
+		}
+	}()
+	a.responseHeaders = headersToMap(headers)
+	if enc := a.responseHeaders["content-encoding"]; enc != "" {
+		a.responseEncoding = enc
+	}
+	if a.translator == nil {
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseHeaders{
+			ResponseHeaders: &extprocv3.HeadersResponse{},
+		}}, nil
+	}
+	headerMutation, err := a.translator.ResponseHeaders(a.responseHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform response headers: %w", err)
+	}
+	return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseHeaders{
+		ResponseHeaders: &extprocv3.HeadersResponse{
+			Response: &extprocv3.CommonResponse{HeaderMutation: headerMutation},
+		},
+	}, ModeOverride: &extprocv3http.ProcessingMode{ResponseBodyMode: extprocv3http.ProcessingMode_BUFFERED}}, nil
+}
+
+// ProcessResponseBody implements [Processor.ProcessResponseBody].
+func (a *audioSpeechProcessor) ProcessResponseBody(ctx context.Context, body *extprocv3.HttpBody) (res *extprocv3.ProcessingResponse, err error) {
+	defer func() {
+		a.metrics.RecordRequestCompletion(ctx, err == nil)
+	}()
+	var br io.Reader
+	switch a.responseEncoding {
+	case "gzip":
+		br, err = gzip.NewReader(bytes.NewReader(body.Body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode gzip: %w", err)
+		}
+	default:
+		br = bytes.NewReader(body.Body)
+	}
+	if a.translator == nil {
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseBody{}}, nil
+	}
+
+	headerMutation, bodyMutation, audioSeconds, err := a.translator.ResponseBody(a.responseHeaders, br, body.EndOfStream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform response: %w", err)
+	}
+
+	a.metrics.RecordAudioSeconds(ctx, audioSeconds)
+
+	resp := &extprocv3.ProcessingResponse{
+		Response: &extprocv3.ProcessingResponse_ResponseBody{
+			ResponseBody: &extprocv3.BodyResponse{
+				Response: &extprocv3.CommonResponse{
+					HeaderMutation: headerMutation,
+					BodyMutation:   bodyMutation,
+				},
+			},
+		},
+	}
+	if body.EndOfStream && len(a.config.requestCosts) > 0 {
+		dm, err := buildSingleValueDynamicMetadata(a.config, a.logger, filterapi.LLMRequestCostTypeAudioSeconds, uint32(audioSeconds)) //nolint:gosec
+		if err != nil {
+			return nil, fmt.Errorf("failed to build dynamic metadata: %w", err)
+		}
+		resp.DynamicMetadata = dm
+	}
+	return resp, nil
+}
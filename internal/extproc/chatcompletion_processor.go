@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"time"
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	extprocv3http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
@@ -28,6 +29,20 @@ import (
 	"github.com/envoyproxy/ai-gateway/internal/llmcostcel"
 )
 
+// timeoutHeaderKey is the request header clients use to override the
+// Gateway's default per-request deadline for a single request.
+const timeoutHeaderKey = "x-ai-eg-timeout"
+
+const (
+	// dynamicMetadataEmitEveryChunks bounds how many streamed response chunks
+	// may pass between DynamicMetadata emissions, so that cost data reaches
+	// rate limiters well before a long-running stream ends.
+	dynamicMetadataEmitEveryChunks = 10
+	// dynamicMetadataEmitInterval bounds how long may elapse between
+	// DynamicMetadata emissions, independent of chunk volume.
+	dynamicMetadataEmitInterval = 500 * time.Millisecond
+)
+
 // ChatCompletionProcessorFactory returns a factory method to instantiate the chat completion processor.
 func ChatCompletionProcessorFactory(ccm x.ChatCompletionMetrics) ProcessorFactory {
 	return func(config *processorConfig, requestHeaders map[string]string, logger *slog.Logger) (Processor, error) {
@@ -57,27 +72,42 @@ type chatCompletionProcessor struct {
 	metrics x.ChatCompletionMetrics
 	// stream is set to true if the request is a streaming request.
 	stream bool
+	// model is the model requested by the client, set once ProcessRequestBody
+	// has run.
+	model string
 	// dynamicLB is not nil if the originally selected backend has dynamic load balancing.
-	// TODO: this is not currently used but can be used to do a failover to the whole another backend as per the
-	// the comment in https://github.com/envoyproxy/ai-gateway/issues/34#issuecomment-2743810926.
 	dynamicLB *filterapi.DynamicLoadBalancing
+	// deadline tracks the request-level deadline, once one has been
+	// established in ProcessRequestBody. It remains nil if no deadline
+	// (neither a default nor an override) applies to this request.
+	deadline *deadlineTimer
+	// chunksSinceDynamicMetadata and lastDynamicMetadataEmit track how long
+	// it's been since DynamicMetadata was last emitted for a streaming
+	// request, so cost data can be pushed out mid-stream rather than only
+	// once the stream ends.
+	chunksSinceDynamicMetadata int
+	lastDynamicMetadataEmit    time.Time
 }
 
-// selectTranslator selects the translator based on the output schema.
-func (c *chatCompletionProcessor) selectTranslator(out filterapi.VersionedAPISchema) error {
+// selectTranslator selects the translator based on the selected backend.
+func (c *chatCompletionProcessor) selectTranslator(b *filterapi.Backend) error {
 	if c.translator != nil { // Prevents re-selection and allows translator injection in tests.
 		return nil
 	}
-	// TODO: currently, we ignore the LLMAPISchema."Version" field.
-	switch out.Name {
+	switch b.Schema.Name {
 	case filterapi.APISchemaOpenAI:
 		c.translator = translator.NewChatCompletionOpenAIToOpenAITranslator()
 	case filterapi.APISchemaAWSBedrock:
 		c.translator = translator.NewChatCompletionOpenAIToAWSBedrockTranslator()
 	case filterapi.APISchemaAzureOpenAI:
-		c.translator = translator.NewChatCompletionOpenAIToAzureOpenAITranslator(out.Version)
+		if b.AzureOpenAI == nil {
+			return fmt.Errorf("backend %q declares schema %s but has no AzureOpenAI deployment configuration", b.Name, b.Schema)
+		}
+		c.translator = translator.NewChatCompletionOpenAIToAzureOpenAITranslator(b.Schema.Version, b.AzureOpenAI.Deployments)
+	case filterapi.APISchemaCohere:
+		c.translator = translator.NewChatCompletionOpenAIToCohereTranslator()
 	default:
-		return fmt.Errorf("unsupported API schema: backend=%s", out)
+		return fmt.Errorf("unsupported API schema: backend=%s", b.Schema)
 	}
 	return nil
 }
@@ -106,9 +136,10 @@ func (c *chatCompletionProcessor) ProcessRequestBody(ctx context.Context, rawBod
 	}
 	c.logger.Info("processing request body", "path", c.requestHeaders[":path"], "model", model)
 
+	c.model = model
 	c.metrics.SetModel(model)
 	c.requestHeaders[c.config.modelNameHeaderKey] = model
-	b, err := c.config.router.Calculate(c.requestHeaders)
+	b, route, err := c.config.router.Calculate(c.requestHeaders)
 	if err != nil {
 		if errors.Is(err, x.ErrNoMatchingRule) {
 			c.metrics.RecordRequestCompletion(ctx, false)
@@ -123,6 +154,9 @@ func (c *chatCompletionProcessor) ProcessRequestBody(ctx context.Context, rawBod
 		}
 		return nil, fmt.Errorf("failed to calculate route: %w", err)
 	}
+	if d := c.requestDeadline(route); d > 0 {
+		c.deadline = newDeadlineTimer(d)
+	}
 
 	var headers []*corev3.HeaderValueOption
 	c.dynamicLB = b.DynamicLoadBalancing
@@ -147,7 +181,7 @@ func (c *chatCompletionProcessor) ProcessRequestBody(ctx context.Context, rawBod
 	c.logger.Info("selected backend", "backend", b.Name, "schema", b.Schema)
 	c.metrics.SetBackend(b)
 
-	if err = c.selectTranslator(b.Schema); err != nil {
+	if err = c.selectTranslator(b); err != nil {
 		return nil, fmt.Errorf("failed to select translator: %w", err)
 	}
 
@@ -186,9 +220,67 @@ func (c *chatCompletionProcessor) ProcessRequestBody(ctx context.Context, rawBod
 		},
 	}
 	c.stream = body.Stream
+	c.lastDynamicMetadataEmit = time.Now()
 	return resp, nil
 }
 
+// requestDeadline determines the deadline to enforce for this request: the
+// x-ai-eg-timeout request header takes precedence over the route's Timeout,
+// which in turn takes precedence over the Gateway's configured default. A
+// returned value of zero means no deadline should be enforced.
+func (c *chatCompletionProcessor) requestDeadline(route *filterapi.Route) time.Duration {
+	deadline := c.config.defaultDeadline
+	if route != nil && route.Timeout != nil {
+		deadline = *route.Timeout
+	}
+	if v := c.requestHeaders[timeoutHeaderKey]; v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			deadline = d
+		}
+	}
+	return deadline
+}
+
+// deadlineExceededMessage is the error body reported once the request's
+// deadline has fired, shared by both the plain-JSON and SSE encodings.
+const deadlineExceededMessage = `{"error":{"message":"request exceeded its deadline","type":"timeout"}}`
+
+// handleDeadlineExceeded builds the ProcessingResponse to return once the
+// request's deadline has fired. If the response hasn't started yet, it
+// rejects the request outright with a 504; otherwise it terminates the
+// in-flight response with a deadlineExceededMessage error body, SSE-framed
+// for a streaming request and plain JSON for a non-streaming one, so the
+// client can tell the response is incomplete rather than silently
+// truncated or, for a non-streaming request, handed invalid JSON.
+func (c *chatCompletionProcessor) handleDeadlineExceeded(ctx context.Context, responseStarted bool) (*extprocv3.ProcessingResponse, error) {
+	c.metrics.RecordRequestCompletion(ctx, false)
+	if !responseStarted {
+		return &extprocv3.ProcessingResponse{
+			Response: &extprocv3.ProcessingResponse_ImmediateResponse{
+				ImmediateResponse: &extprocv3.ImmediateResponse{
+					Status: &typev3.HttpStatus{Code: typev3.StatusCode_GatewayTimeout},
+					Body:   []byte(deadlineExceededMessage),
+				},
+			},
+		}, nil
+	}
+	body := []byte(deadlineExceededMessage)
+	if c.stream {
+		body = []byte("data: " + deadlineExceededMessage + "\n\ndata: [DONE]\n\n")
+	}
+	return &extprocv3.ProcessingResponse{
+		Response: &extprocv3.ProcessingResponse_ResponseBody{
+			ResponseBody: &extprocv3.BodyResponse{
+				Response: &extprocv3.CommonResponse{
+					BodyMutation: &extprocv3.BodyMutation{
+						Mutation: &extprocv3.BodyMutation_Body{Body: body},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
 // ProcessResponseHeaders implements [Processor.ProcessResponseHeaders].
 func (c *chatCompletionProcessor) ProcessResponseHeaders(ctx context.Context, headers *corev3.HeaderMap) (res *extprocv3.ProcessingResponse, err error) {
 	defer func() {
@@ -196,10 +288,13 @@ func (c *chatCompletionProcessor) ProcessResponseHeaders(ctx context.Context, he
 			c.metrics.RecordRequestCompletion(ctx, false)
 		}
 	}()
-	// TODO: check the status code and use the dynamic load balancing to retry the request per the comment in
-	// 	https://github.com/envoyproxy/ai-gateway/issues/34#issuecomment-2743810926
-	_ = c.dynamicLB
-
+	if c.deadline != nil {
+		select {
+		case <-c.deadline.Done():
+			return c.handleDeadlineExceeded(ctx, false)
+		default:
+		}
+	}
 	c.responseHeaders = headersToMap(headers)
 	if enc := c.responseHeaders["content-encoding"]; enc != "" {
 		c.responseEncoding = enc
@@ -229,9 +324,22 @@ func (c *chatCompletionProcessor) ProcessResponseHeaders(ctx context.Context, he
 
 // ProcessResponseBody implements [Processor.ProcessResponseBody].
 func (c *chatCompletionProcessor) ProcessResponseBody(ctx context.Context, body *extprocv3.HttpBody) (res *extprocv3.ProcessingResponse, err error) {
+	deadlineExceeded := false
 	defer func() {
+		if deadlineExceeded {
+			// handleDeadlineExceeded already recorded the (failed) completion.
+			return
+		}
 		c.metrics.RecordRequestCompletion(ctx, err == nil)
 	}()
+	if c.deadline != nil {
+		select {
+		case <-c.deadline.Done():
+			deadlineExceeded = true
+			return c.handleDeadlineExceeded(ctx, true)
+		default:
+		}
+	}
 	var br io.Reader
 	switch c.responseEncoding {
 	case "gzip":
@@ -264,7 +372,10 @@ func (c *chatCompletionProcessor) ProcessResponseBody(ctx context.Context, body
 		},
 	}
 
-	// TODO: we need to investigate if we need to accumulate the token usage for streaming responses.
+	// Each ResponseBody call reports tokenUsage as an increment over what the
+	// translator has already reported for this stream, so accumulating it
+	// here is correct whether the response is streamed across many calls or
+	// returned in one.
 	c.costs.InputTokens += tokenUsage.InputTokens
 	c.costs.OutputTokens += tokenUsage.OutputTokens
 	c.costs.TotalTokens += tokenUsage.TotalTokens
@@ -277,11 +388,17 @@ func (c *chatCompletionProcessor) ProcessResponseBody(ctx context.Context, body
 		c.metrics.RecordTokenLatency(ctx, tokenUsage.OutputTokens)
 	}
 
-	if body.EndOfStream && len(c.config.requestCosts) > 0 {
+	if len(c.config.requestCosts) > 0 && c.shouldEmitDynamicMetadata(body.EndOfStream) {
 		resp.DynamicMetadata, err = c.maybeBuildDynamicMetadata()
 		if err != nil {
 			return nil, fmt.Errorf("failed to build dynamic metadata: %w", err)
 		}
+		c.chunksSinceDynamicMetadata = 0
+		c.lastDynamicMetadataEmit = time.Now()
+	}
+
+	if body.EndOfStream && c.deadline != nil {
+		c.deadline.Stop()
 	}
 
 	return resp, nil
@@ -295,6 +412,28 @@ func parseOpenAIChatCompletionBody(body *extprocv3.HttpBody) (modelName string,
 	return openAIReq.Model, &openAIReq, nil
 }
 
+// shouldEmitDynamicMetadata reports whether this ProcessResponseBody call
+// should carry a DynamicMetadata update. The end of the stream always
+// emits. For a non-streaming request, only the end of the stream emits,
+// since c.costs isn't complete before then. For a streaming request, it
+// also emits every dynamicMetadataEmitEveryChunks chunks or
+// dynamicMetadataEmitInterval, whichever comes first, so that rate limiters
+// watching the cost metadata namespace can throttle or terminate a
+// runaway generation before it finishes.
+func (c *chatCompletionProcessor) shouldEmitDynamicMetadata(endOfStream bool) bool {
+	if endOfStream {
+		return true
+	}
+	if !c.stream {
+		return false
+	}
+	c.chunksSinceDynamicMetadata++
+	if c.chunksSinceDynamicMetadata >= dynamicMetadataEmitEveryChunks {
+		return true
+	}
+	return !c.lastDynamicMetadataEmit.IsZero() && time.Since(c.lastDynamicMetadataEmit) >= dynamicMetadataEmitInterval
+}
+
 func (c *chatCompletionProcessor) maybeBuildDynamicMetadata() (*structpb.Struct, error) {
 	metadata := make(map[string]*structpb.Value, len(c.config.requestCosts))
 	for i := range c.config.requestCosts {
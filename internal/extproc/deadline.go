@@ -0,0 +1,69 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package extproc
+
+import (
+	"sync"
+	"time"
+)
+
+// deadlineTimer tracks a single request-level deadline. It exposes a channel
+// that is closed exactly once, when the deadline fires, so that any
+// goroutine blocked on a streaming read can select on it to unblock
+// promptly instead of waiting for the read to complete on its own.
+//
+// Reset replaces done and once with fresh values rather than reusing them,
+// since a sync.Once that has already fired never fires again: without this,
+// a deadlineTimer that had already expired once would report every future
+// deadline as immediately expired, regardless of how much time remained.
+type deadlineTimer struct {
+	mu    sync.Mutex
+	timer *time.Timer
+	done  chan struct{}
+	once  *sync.Once
+}
+
+// newDeadlineTimer starts a deadlineTimer that fires after d.
+func newDeadlineTimer(d time.Duration) *deadlineTimer {
+	dt := &deadlineTimer{}
+	dt.Reset(d)
+	return dt
+}
+
+// Reset reschedules the deadline to fire after d from now, replacing
+// whatever deadline was previously scheduled.
+func (dt *deadlineTimer) Reset(d time.Duration) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	if dt.timer != nil {
+		dt.timer.Stop()
+	}
+	done := make(chan struct{})
+	once := &sync.Once{}
+	dt.done = done
+	dt.once = once
+	dt.timer = time.AfterFunc(d, func() {
+		once.Do(func() { close(done) })
+	})
+}
+
+// Done returns a channel that is closed once the deadline fires. The
+// returned channel reflects whatever deadline was most recently scheduled
+// as of this call; a later Reset replaces it with a new, open channel.
+func (dt *deadlineTimer) Done() <-chan struct{} {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	return dt.done
+}
+
+// Stop cancels the pending deadline, e.g. once the request has completed.
+func (dt *deadlineTimer) Stop() {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+	if dt.timer != nil {
+		dt.timer.Stop()
+	}
+}
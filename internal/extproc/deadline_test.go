@@ -0,0 +1,44 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package extproc
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeadlineTimer_ResetAfterFire(t *testing.T) {
+	dt := newDeadlineTimer(time.Millisecond)
+
+	select {
+	case <-dt.Done():
+	case <-time.After(time.Second):
+		t.Fatal("deadline never fired")
+	}
+
+	dt.Reset(time.Hour)
+
+	select {
+	case <-dt.Done():
+		t.Fatal("Done() reported expired immediately after Reset with a long duration")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	dt.Stop()
+}
+
+func TestDeadlineTimer_ResetBeforeFireCancelsPreviousDeadline(t *testing.T) {
+	dt := newDeadlineTimer(time.Millisecond)
+	dt.Reset(time.Hour)
+
+	select {
+	case <-dt.Done():
+		t.Fatal("Done() fired despite being reset to a much later deadline")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	dt.Stop()
+}
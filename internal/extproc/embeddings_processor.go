@@ -0,0 +1,221 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package extproc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+
+	"github.com/envoyproxy/ai-gateway/filterapi"
+	"github.com/envoyproxy/ai-gateway/filterapi/x"
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+	"github.com/envoyproxy/ai-gateway/internal/extproc/translator"
+)
+
+// EmbeddingsProcessorFactory returns a factory method to instantiate the
+// embeddings processor.
+func EmbeddingsProcessorFactory(em x.EmbeddingsMetrics) ProcessorFactory {
+	return func(config *processorConfig, requestHeaders map[string]string, logger *slog.Logger) (Processor, error) {
+		if config.schema.Name != filterapi.APISchemaOpenAI {
+			return nil, fmt.Errorf("unsupported API schema: %s", config.schema.Name)
+		}
+		return &embeddingsProcessor{
+			config:         config,
+			requestHeaders: requestHeaders,
+			logger:         logger,
+			metrics:        em,
+		}, nil
+	}
+}
+
+// embeddingsProcessor handles the processing of the request and response
+// messages for a single embeddings stream.
+type embeddingsProcessor struct {
+	logger           *slog.Logger
+	config           *processorConfig
+	requestHeaders   map[string]string
+	responseHeaders  map[string]string
+	responseEncoding string
+	translator       translator.OpenAIEmbeddingsTranslator
+	metrics          x.EmbeddingsMetrics
+}
+
+func (e *embeddingsProcessor) selectTranslator(out filterapi.VersionedAPISchema) error {
+	if e.translator != nil {
+		return nil
+	}
+	switch out.Name {
+	case filterapi.APISchemaOpenAI:
+		e.translator = translator.NewEmbeddingsOpenAIToOpenAITranslator()
+	case filterapi.APISchemaAWSBedrock:
+		e.translator = translator.NewEmbeddingsOpenAIToAWSBedrockTranslator()
+	default:
+		return fmt.Errorf("unsupported API schema: backend=%s", out)
+	}
+	return nil
+}
+
+// ProcessRequestHeaders implements [Processor.ProcessRequestHeaders].
+func (e *embeddingsProcessor) ProcessRequestHeaders(_ context.Context, _ *corev3.HeaderMap) (*extprocv3.ProcessingResponse, error) {
+	e.metrics.StartRequest(e.requestHeaders)
+	return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestHeaders{
+		RequestHeaders: &extprocv3.HeadersResponse{},
+	}}, nil
+}
+
+// ProcessRequestBody implements [Processor.ProcessRequestBody].
+func (e *embeddingsProcessor) ProcessRequestBody(ctx context.Context, rawBody *extprocv3.HttpBody) (res *extprocv3.ProcessingResponse, err error) {
+	defer func() {
+		if err != nil {
+			e.metrics.RecordRequestCompletion(ctx, false)
+		}
+	}()
+	var body openai.EmbeddingRequest
+	if err = json.Unmarshal(rawBody.Body, &body); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal body: %w", err)
+	}
+	e.logger.Info("processing embeddings request body", "path", e.requestHeaders[":path"], "model", body.Model)
+
+	e.metrics.SetModel(body.Model)
+	e.requestHeaders[e.config.modelNameHeaderKey] = body.Model
+	b, _, err := e.config.router.Calculate(e.requestHeaders)
+	if err != nil {
+		if errors.Is(err, x.ErrNoMatchingRule) {
+			e.metrics.RecordRequestCompletion(ctx, false)
+			return &extprocv3.ProcessingResponse{
+				Response: &extprocv3.ProcessingResponse_ImmediateResponse{
+					ImmediateResponse: &extprocv3.ImmediateResponse{
+						Status: &typev3.HttpStatus{Code: typev3.StatusCode_NotFound},
+						Body:   []byte(err.Error()),
+					},
+				},
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to calculate route: %w", err)
+	}
+
+	e.logger.Info("selected backend", "backend", b.Name, "schema", b.Schema)
+	e.metrics.SetBackend(b)
+
+	if err = e.selectTranslator(b.Schema); err != nil {
+		return nil, fmt.Errorf("failed to select translator: %w", err)
+	}
+
+	headerMutation, bodyMutation, err := e.translator.RequestBody(&body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform request: %w", err)
+	}
+	if headerMutation == nil {
+		headerMutation = &extprocv3.HeaderMutation{}
+	}
+	headerMutation.SetHeaders = append(headerMutation.SetHeaders, &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{Key: e.config.modelNameHeaderKey, RawValue: []byte(body.Model)},
+	}, &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{Key: e.config.selectedBackendHeaderKey, RawValue: []byte(b.Name)},
+	})
+	if authHandler, ok := e.config.backendAuthHandlers[b.Name]; ok {
+		if err = authHandler.Do(ctx, e.requestHeaders, headerMutation, bodyMutation); err != nil {
+			return nil, fmt.Errorf("failed to do auth request: %w", err)
+		}
+	}
+
+	return &extprocv3.ProcessingResponse{
+		Response: &extprocv3.ProcessingResponse_RequestBody{
+			RequestBody: &extprocv3.BodyResponse{
+				Response: &extprocv3.CommonResponse{
+					HeaderMutation:  headerMutation,
+					BodyMutation:    bodyMutation,
+					ClearRouteCache: true,
+				},
+			},
+		},
+	}, nil
+}
+
+// ProcessResponseHeaders implements [Processor.ProcessResponseHeaders].
+func (e *embeddingsProcessor) ProcessResponseHeaders(ctx context.Context, headers *corev3.HeaderMap) (res *extprocv3.ProcessingResponse, err error) {
+	defer func() {
+		if err != nil {
+			e.metrics.RecordRequestCompletion(ctx, false)
+		}
+	}()
+	e.responseHeaders = headersToMap(headers)
+	if enc := e.responseHeaders["content-encoding"]; enc != "" {
+		e.responseEncoding = enc
+	}
+	if e.translator == nil {
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseHeaders{
+			ResponseHeaders: &extprocv3.HeadersResponse{},
+		}}, nil
+	}
+	headerMutation, err := e.translator.ResponseHeaders(e.responseHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform response headers: %w", err)
+	}
+	return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseHeaders{
+		ResponseHeaders: &extprocv3.HeadersResponse{
+			Response: &extprocv3.CommonResponse{HeaderMutation: headerMutation},
+		},
+	}, ModeOverride: &extprocv3http.ProcessingMode{ResponseBodyMode: extprocv3http.ProcessingMode_BUFFERED}}, nil
+}
+
+// ProcessResponseBody implements [Processor.ProcessResponseBody].
+func (e *embeddingsProcessor) ProcessResponseBody(ctx context.Context, body *extprocv3.HttpBody) (res *extprocv3.ProcessingResponse, err error) {
+	defer func() {
+		e.metrics.RecordRequestCompletion(ctx, err == nil)
+	}()
+	var br io.Reader
+	switch e.responseEncoding {
+	case "gzip":
+		br, err = gzip.NewReader(bytes.NewReader(body.Body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode gzip: %w", err)
+		}
+	default:
+		br = bytes.NewReader(body.Body)
+	}
+	if e.translator == nil {
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseBody{}}, nil
+	}
+
+	headerMutation, bodyMutation, inputTokens, embeddingsCount, err := e.translator.ResponseBody(e.responseHeaders, br, body.EndOfStream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform response: %w", err)
+	}
+
+	e.metrics.RecordTokenUsage(ctx, inputTokens)
+	e.metrics.RecordEmbeddingsCount(ctx, embeddingsCount)
+
+	resp := &extprocv3.ProcessingResponse{
+		Response: &extprocv3.ProcessingResponse_ResponseBody{
+			ResponseBody: &extprocv3.BodyResponse{
+				Response: &extprocv3.CommonResponse{
+					HeaderMutation: headerMutation,
+					BodyMutation:   bodyMutation,
+				},
+			},
+		},
+	}
+	if body.EndOfStream && len(e.config.requestCosts) > 0 {
+		dm, err := buildSingleValueDynamicMetadata(e.config, e.logger, filterapi.LLMRequestCostTypeInputToken, inputTokens)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build dynamic metadata: %w", err)
+		}
+		resp.DynamicMetadata = dm
+	}
+	return resp, nil
+}
@@ -0,0 +1,218 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package extproc
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3http "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+
+	"github.com/envoyproxy/ai-gateway/filterapi"
+	"github.com/envoyproxy/ai-gateway/filterapi/x"
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+	"github.com/envoyproxy/ai-gateway/internal/extproc/translator"
+)
+
+// ImagesProcessorFactory returns a factory method to instantiate the image
+// generation processor.
+func ImagesProcessorFactory(im x.ImagesMetrics) ProcessorFactory {
+	return func(config *processorConfig, requestHeaders map[string]string, logger *slog.Logger) (Processor, error) {
+		if config.schema.Name != filterapi.APISchemaOpenAI {
+			return nil, fmt.Errorf("unsupported API schema: %s", config.schema.Name)
+		}
+		return &imagesProcessor{
+			config:         config,
+			requestHeaders: requestHeaders,
+			logger:         logger,
+			metrics:        im,
+		}, nil
+	}
+}
+
+// imagesProcessor handles the processing of the request and response
+// messages for a single image generation stream.
+type imagesProcessor struct {
+	logger           *slog.Logger
+	config           *processorConfig
+	requestHeaders   map[string]string
+	responseHeaders  map[string]string
+	responseEncoding string
+	translator       translator.OpenAIImagesTranslator
+	metrics          x.ImagesMetrics
+}
+
+func (p *imagesProcessor) selectTranslator(out filterapi.VersionedAPISchema) error {
+	if p.translator != nil {
+		return nil
+	}
+	switch out.Name {
+	case filterapi.APISchemaOpenAI:
+		p.translator = translator.NewImagesOpenAIToOpenAITranslator()
+	default:
+		return fmt.Errorf("unsupported API schema: backend=%s", out)
+	}
+	return nil
+}
+
+// ProcessRequestHeaders implements [Processor.ProcessRequestHeaders].
+func (p *imagesProcessor) ProcessRequestHeaders(_ context.Context, _ *corev3.HeaderMap) (*extprocv3.ProcessingResponse, error) {
+	p.metrics.StartRequest(p.requestHeaders)
+	return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_RequestHeaders{
+		RequestHeaders: &extprocv3.HeadersResponse{},
+	}}, nil
+}
+
+// ProcessRequestBody implements [Processor.ProcessRequestBody].
+func (p *imagesProcessor) ProcessRequestBody(ctx context.Context, rawBody *extprocv3.HttpBody) (res *extprocv3.ProcessingResponse, err error) {
+	defer func() {
+		if err != nil {
+			p.metrics.RecordRequestCompletion(ctx, false)
+		}
+	}()
+	var body openai.ImageGenerationRequest
+	if err = json.Unmarshal(rawBody.Body, &body); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal body: %w", err)
+	}
+	p.logger.Info("processing image generation request body", "path", p.requestHeaders[":path"], "model", body.Model)
+
+	p.metrics.SetModel(body.Model)
+	p.requestHeaders[p.config.modelNameHeaderKey] = body.Model
+	b, _, err := p.config.router.Calculate(p.requestHeaders)
+	if err != nil {
+		if errors.Is(err, x.ErrNoMatchingRule) {
+			p.metrics.RecordRequestCompletion(ctx, false)
+			return &extprocv3.ProcessingResponse{
+				Response: &extprocv3.ProcessingResponse_ImmediateResponse{
+					ImmediateResponse: &extprocv3.ImmediateResponse{
+						Status: &typev3.HttpStatus{Code: typev3.StatusCode_NotFound},
+						Body:   []byte(err.Error()),
+					},
+				},
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to calculate route: %w", err)
+	}
+
+	p.logger.Info("selected backend", "backend", b.Name, "schema", b.Schema)
+	p.metrics.SetBackend(b)
+
+	if err = p.selectTranslator(b.Schema); err != nil {
+		return nil, fmt.Errorf("failed to select translator: %w", err)
+	}
+
+	headerMutation, bodyMutation, err := p.translator.RequestBody(&body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform request: %w", err)
+	}
+	if headerMutation == nil {
+		headerMutation = &extprocv3.HeaderMutation{}
+	}
+	headerMutation.SetHeaders = append(headerMutation.SetHeaders, &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{Key: p.config.modelNameHeaderKey, RawValue: []byte(body.Model)},
+	}, &corev3.HeaderValueOption{
+		Header: &corev3.HeaderValue{Key: p.config.selectedBackendHeaderKey, RawValue: []byte(b.Name)},
+	})
+	if authHandler, ok := p.config.backendAuthHandlers[b.Name]; ok {
+		if err = authHandler.Do(ctx, p.requestHeaders, headerMutation, bodyMutation); err != nil {
+			return nil, fmt.Errorf("failed to do auth request: %w", err)
+		}
+	}
+
+	return &extprocv3.ProcessingResponse{
+		Response: &extprocv3.ProcessingResponse_RequestBody{
+			RequestBody: &extprocv3.BodyResponse{
+				Response: &extprocv3.CommonResponse{
+					HeaderMutation:  headerMutation,
+					BodyMutation:    bodyMutation,
+					ClearRouteCache: true,
+				},
+			},
+		},
+	}, nil
+}
+
+// ProcessResponseHeaders implements [Processor.ProcessResponseHeaders].
+func (p *imagesProcessor) ProcessResponseHeaders(ctx context.Context, headers *corev3.HeaderMap) (res *extprocv3.ProcessingResponse, err error) {
+	defer func() {
+		if err != nil {
+			p.metrics.RecordRequestCompletion(ctx, false)
+		}
+	}()
+	p.responseHeaders = headersToMap(headers)
+	if enc := p.responseHeaders["content-encoding"]; enc != "" {
+		p.responseEncoding = enc
+	}
+	if p.translator == nil {
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseHeaders{
+			ResponseHeaders: &extprocv3.HeadersResponse{},
+		}}, nil
+	}
+	headerMutation, err := p.translator.ResponseHeaders(p.responseHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform response headers: %w", err)
+	}
+	return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseHeaders{
+		ResponseHeaders: &extprocv3.HeadersResponse{
+			Response: &extprocv3.CommonResponse{HeaderMutation: headerMutation},
+		},
+	}, ModeOverride: &extprocv3http.ProcessingMode{ResponseBodyMode: extprocv3http.ProcessingMode_BUFFERED}}, nil
+}
+
+// ProcessResponseBody implements [Processor.ProcessResponseBody].
+func (p *imagesProcessor) ProcessResponseBody(ctx context.Context, body *extprocv3.HttpBody) (res *extprocv3.ProcessingResponse, err error) {
+	defer func() {
+		p.metrics.RecordRequestCompletion(ctx, err == nil)
+	}()
+	var br io.Reader
+	switch p.responseEncoding {
+	case "gzip":
+		br, err = gzip.NewReader(bytes.NewReader(body.Body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode gzip: %w", err)
+		}
+	default:
+		br = bytes.NewReader(body.Body)
+	}
+	if p.translator == nil {
+		return &extprocv3.ProcessingResponse{Response: &extprocv3.ProcessingResponse_ResponseBody{}}, nil
+	}
+
+	headerMutation, bodyMutation, imageCount, err := p.translator.ResponseBody(p.responseHeaders, br, body.EndOfStream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform response: %w", err)
+	}
+
+	p.metrics.RecordImageCount(ctx, imageCount)
+
+	resp := &extprocv3.ProcessingResponse{
+		Response: &extprocv3.ProcessingResponse_ResponseBody{
+			ResponseBody: &extprocv3.BodyResponse{
+				Response: &extprocv3.CommonResponse{
+					HeaderMutation: headerMutation,
+					BodyMutation:   bodyMutation,
+				},
+			},
+		},
+	}
+	if body.EndOfStream && len(p.config.requestCosts) > 0 {
+		dm, err := buildSingleValueDynamicMetadata(p.config, p.logger, filterapi.LLMRequestCostTypeImages, imageCount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build dynamic metadata: %w", err)
+		}
+		resp.DynamicMetadata = dm
+	}
+	return resp, nil
+}
@@ -0,0 +1,131 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package extproc
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/envoyproxy/ai-gateway/filterapi"
+	"github.com/envoyproxy/ai-gateway/filterapi/x"
+	"github.com/envoyproxy/ai-gateway/internal/llmcostcel"
+)
+
+// Processor handles the Envoy ext_proc events for a single HTTP stream.
+type Processor interface {
+	// ProcessRequestHeaders handles the request headers event.
+	ProcessRequestHeaders(ctx context.Context, headers *corev3.HeaderMap) (*extprocv3.ProcessingResponse, error)
+	// ProcessRequestBody handles the request body event.
+	ProcessRequestBody(ctx context.Context, body *extprocv3.HttpBody) (*extprocv3.ProcessingResponse, error)
+	// ProcessResponseHeaders handles the response headers event.
+	ProcessResponseHeaders(ctx context.Context, headers *corev3.HeaderMap) (*extprocv3.ProcessingResponse, error)
+	// ProcessResponseBody handles the response body event.
+	ProcessResponseBody(ctx context.Context, body *extprocv3.HttpBody) (*extprocv3.ProcessingResponse, error)
+}
+
+// ProcessorFactory instantiates a [Processor] for a single stream, given the
+// static processor configuration and the request headers received so far.
+type ProcessorFactory func(config *processorConfig, requestHeaders map[string]string, logger *slog.Logger) (Processor, error)
+
+// Router calculates the backend that a request should be routed to based on
+// the request headers, including the model name header set by the processor.
+type Router interface {
+	// Calculate returns the backend selected for requestHeaders and the route
+	// it was matched against, or [x.ErrNoMatchingRule] if no rule matches.
+	// The returned route is nil if the matched rule isn't associated with any
+	// per-route overrides.
+	Calculate(requestHeaders map[string]string) (*filterapi.Backend, *filterapi.Route, error)
+}
+
+// AuthHandler mutates the outgoing request to attach backend-specific
+// authentication, such as signing the request or injecting an API key.
+type AuthHandler interface {
+	// Do attaches authentication to the request described by headerMutation
+	// and bodyMutation.
+	Do(ctx context.Context, requestHeaders map[string]string, headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation) error
+}
+
+// DynamicLoadBalancer resolves a concrete endpoint for a backend configured
+// with [filterapi.DynamicLoadBalancing].
+type DynamicLoadBalancer interface {
+	// SelectChatCompletionsEndpoint selects an endpoint for model. It returns
+	// the backend to report in metrics and any additional headers that must
+	// be set on the request to route to the selected endpoint.
+	SelectChatCompletionsEndpoint(model string, metrics x.ChatCompletionMetrics) (*filterapi.Backend, []*corev3.HeaderValueOption, error)
+}
+
+// requestCost is the runtime representation of a [filterapi.LLMRequestCost]
+// with its CEL expression, if any, already compiled.
+type requestCost struct {
+	filterapi.LLMRequestCost
+	celProg *llmcostcel.Program
+}
+
+// processorConfig is the static, shared configuration built once when the
+// external processor starts and handed to every [Processor] instance.
+type processorConfig struct {
+	schema                   filterapi.VersionedAPISchema
+	router                   Router
+	modelNameHeaderKey       string
+	selectedBackendHeaderKey string
+	metadataNamespace        string
+	backendAuthHandlers      map[string]AuthHandler
+	dynamicLoadBalancers     map[*filterapi.DynamicLoadBalancing]DynamicLoadBalancer
+	requestCosts             []requestCost
+	// defaultDeadline is the request-level deadline applied when neither the
+	// matched route nor the x-ai-eg-timeout request header specify one. Zero
+	// means no default deadline is enforced.
+	defaultDeadline time.Duration
+}
+
+// buildSingleValueDynamicMetadata builds the DynamicMetadata struct for
+// processors that, unlike chat completions, only ever have a single cost
+// value to report (e.g. the image count of an images request, or the audio
+// seconds of an audio request). costType is the only [filterapi.LLMRequestCostType]
+// such a processor can satisfy; any configured cost entry of a different
+// type is skipped, matching the behavior of an unconfigured cost.
+func buildSingleValueDynamicMetadata(config *processorConfig, logger *slog.Logger, costType filterapi.LLMRequestCostType, value uint32) (*structpb.Struct, error) {
+	metadata := make(map[string]*structpb.Value, len(config.requestCosts))
+	for i := range config.requestCosts {
+		rc := &config.requestCosts[i]
+		if rc.Type != costType {
+			continue
+		}
+		logger.Info("Setting request cost metadata", "type", rc.Type, "cost", value, "metadataKey", rc.MetadataKey)
+		metadata[rc.MetadataKey] = &structpb.Value{Kind: &structpb.Value_NumberValue{NumberValue: float64(value)}}
+	}
+	if len(metadata) == 0 {
+		return nil, nil
+	}
+	return &structpb.Struct{
+		Fields: map[string]*structpb.Value{
+			config.metadataNamespace: {
+				Kind: &structpb.Value_StructValue{
+					StructValue: &structpb.Struct{Fields: metadata},
+				},
+			},
+		},
+	}, nil
+}
+
+// headersToMap converts an Envoy header map into a plain Go map, keyed by
+// lower-cased header name as Envoy delivers them.
+func headersToMap(headers *corev3.HeaderMap) map[string]string {
+	m := make(map[string]string, len(headers.GetHeaders()))
+	for _, h := range headers.GetHeaders() {
+		if v := h.GetValue(); v != "" {
+			m[h.GetKey()] = v
+		} else {
+			m[h.GetKey()] = string(h.GetRawValue())
+		}
+	}
+	return m
+}
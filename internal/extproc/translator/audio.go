@@ -0,0 +1,101 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"io"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+)
+
+// OpenAIAudioTranscriptionTranslator translates an OpenAI-shaped audio
+// transcription request to a backend-native request, and the backend-native
+// response back to the OpenAI shape.
+type OpenAIAudioTranscriptionTranslator interface {
+	// RequestBody translates an OpenAI AudioTranscriptionRequest into the
+	// corresponding header and body mutations to apply to the outgoing
+	// request.
+	RequestBody(body *openai.AudioTranscriptionRequest) (headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation, err error)
+	// ResponseHeaders translates the backend's response headers into the
+	// header mutation to apply before forwarding to the client.
+	ResponseHeaders(headers map[string]string) (headerMutation *extprocv3.HeaderMutation, err error)
+	// ResponseBody translates the backend's response body into the OpenAI
+	// shape. The audio duration, used for cost accounting, is estimated
+	// from the request body before translation, not by this translator.
+	ResponseBody(responseHeaders map[string]string, body io.Reader, endOfStream bool) (headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation, err error)
+}
+
+// OpenAIAudioSpeechTranslator translates an OpenAI-shaped text-to-speech
+// request to a backend-native request, and the backend-native response back
+// to the OpenAI shape.
+type OpenAIAudioSpeechTranslator interface {
+	// RequestBody translates an OpenAI AudioSpeechRequest into the
+	// corresponding header and body mutations to apply to the outgoing
+	// request.
+	RequestBody(body *openai.AudioSpeechRequest) (headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation, err error)
+	// ResponseHeaders translates the backend's response headers into the
+	// header mutation to apply before forwarding to the client.
+	ResponseHeaders(headers map[string]string) (headerMutation *extprocv3.HeaderMutation, err error)
+	// ResponseBody passes the synthesized audio through, returning the
+	// number of seconds of audio synthesized for cost accounting.
+	ResponseBody(responseHeaders map[string]string, body io.Reader, endOfStream bool) (headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation, audioSeconds float64, err error)
+}
+
+// NewAudioTranscriptionOpenAIToOpenAITranslator returns a translator that
+// passes audio transcription requests and responses through unmodified.
+func NewAudioTranscriptionOpenAIToOpenAITranslator() OpenAIAudioTranscriptionTranslator {
+	return &audioTranscriptionOpenAIToOpenAITranslator{}
+}
+
+type audioTranscriptionOpenAIToOpenAITranslator struct{}
+
+// RequestBody implements [OpenAIAudioTranscriptionTranslator.RequestBody].
+func (a *audioTranscriptionOpenAIToOpenAITranslator) RequestBody(_ *openai.AudioTranscriptionRequest) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, error) {
+	return nil, nil, nil
+}
+
+// ResponseHeaders implements [OpenAIAudioTranscriptionTranslator.ResponseHeaders].
+func (a *audioTranscriptionOpenAIToOpenAITranslator) ResponseHeaders(_ map[string]string) (*extprocv3.HeaderMutation, error) {
+	return nil, nil
+}
+
+// ResponseBody implements [OpenAIAudioTranscriptionTranslator.ResponseBody].
+func (a *audioTranscriptionOpenAIToOpenAITranslator) ResponseBody(_ map[string]string, _ io.Reader, _ bool) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, error) {
+	return nil, nil, nil
+}
+
+// NewAudioSpeechOpenAIToOpenAITranslator returns a translator that passes
+// text-to-speech requests and responses through unmodified.
+func NewAudioSpeechOpenAIToOpenAITranslator() OpenAIAudioSpeechTranslator {
+	return &audioSpeechOpenAIToOpenAITranslator{}
+}
+
+type audioSpeechOpenAIToOpenAITranslator struct {
+	inputLength int
+}
+
+// RequestBody implements [OpenAIAudioSpeechTranslator.RequestBody].
+func (a *audioSpeechOpenAIToOpenAITranslator) RequestBody(body *openai.AudioSpeechRequest) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, error) {
+	a.inputLength = len(body.Input)
+	return nil, nil, nil
+}
+
+// ResponseHeaders implements [OpenAIAudioSpeechTranslator.ResponseHeaders].
+func (a *audioSpeechOpenAIToOpenAITranslator) ResponseHeaders(_ map[string]string) (*extprocv3.HeaderMutation, error) {
+	return nil, nil
+}
+
+// averageCharsPerSecond is a rough heuristic for English speech synthesis,
+// used to estimate the generated duration from the input text's length. The
+// backend's response is not consulted for the duration.
+const averageCharsPerSecond = 16.0
+
+// ResponseBody implements [OpenAIAudioSpeechTranslator.ResponseBody].
+func (a *audioSpeechOpenAIToOpenAITranslator) ResponseBody(_ map[string]string, _ io.Reader, _ bool) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, float64, error) {
+	return nil, nil, float64(a.inputLength) / averageCharsPerSecond, nil
+}
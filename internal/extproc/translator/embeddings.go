@@ -0,0 +1,68 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"io"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+)
+
+// OpenAIEmbeddingsTranslator translates an OpenAI-shaped embeddings request
+// to a backend-native request, and the backend-native response back to the
+// OpenAI shape.
+type OpenAIEmbeddingsTranslator interface {
+	// RequestBody translates an OpenAI EmbeddingRequest into the
+	// corresponding header and body mutations to apply to the outgoing
+	// request.
+	RequestBody(body *openai.EmbeddingRequest) (headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation, err error)
+	// ResponseHeaders translates the backend's response headers into the
+	// header mutation to apply before forwarding to the client.
+	ResponseHeaders(headers map[string]string) (headerMutation *extprocv3.HeaderMutation, err error)
+	// ResponseBody translates the backend's response body into the OpenAI
+	// shape, returning the number of input tokens billed and the number of
+	// embedding vectors returned.
+	ResponseBody(responseHeaders map[string]string, body io.Reader, endOfStream bool) (headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation, inputTokens uint32, embeddingsCount uint32, err error)
+}
+
+// NewEmbeddingsOpenAIToOpenAITranslator returns a translator that passes
+// embeddings requests and responses through unmodified, extracting token and
+// embeddings counts for metrics and cost accounting along the way.
+func NewEmbeddingsOpenAIToOpenAITranslator() OpenAIEmbeddingsTranslator {
+	return &embeddingsOpenAIToOpenAITranslator{}
+}
+
+type embeddingsOpenAIToOpenAITranslator struct{}
+
+// RequestBody implements [OpenAIEmbeddingsTranslator.RequestBody].
+func (e *embeddingsOpenAIToOpenAITranslator) RequestBody(_ *openai.EmbeddingRequest) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, error) {
+	return nil, nil, nil
+}
+
+// ResponseHeaders implements [OpenAIEmbeddingsTranslator.ResponseHeaders].
+func (e *embeddingsOpenAIToOpenAITranslator) ResponseHeaders(_ map[string]string) (*extprocv3.HeaderMutation, error) {
+	return nil, nil
+}
+
+// embeddingResponse is the minimal shape needed to extract usage from an
+// OpenAI embeddings response.
+type embeddingResponse struct {
+	Data  []interface{} `json:"data"`
+	Usage struct {
+		PromptTokens uint32 `json:"prompt_tokens"`
+	} `json:"usage"`
+}
+
+// ResponseBody implements [OpenAIEmbeddingsTranslator.ResponseBody].
+func (e *embeddingsOpenAIToOpenAITranslator) ResponseBody(_ map[string]string, body io.Reader, _ bool) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, uint32, uint32, error) {
+	var resp embeddingResponse
+	if err := decodeJSON(body, &resp); err != nil {
+		return nil, nil, 0, 0, err
+	}
+	return nil, nil, resp.Usage.PromptTokens, uint32(len(resp.Data)), nil
+}
@@ -0,0 +1,51 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"io"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+)
+
+// errEmbeddingsAWSBedrockNotImplemented is returned by every method of
+// embeddingsOpenAIToAWSBedrockTranslator until the Titan Embeddings
+// InvokeModel request/response translation is implemented. Returning it
+// (rather than silently passing the OpenAI-shaped body through and
+// fabricating a token/embeddings count) means a Bedrock embeddings backend
+// fails loudly instead of serving broken responses with fake metrics.
+var errEmbeddingsAWSBedrockNotImplemented = errors.New("AWS Bedrock embeddings translation is not implemented")
+
+// NewEmbeddingsOpenAIToAWSBedrockTranslator returns the translator for an
+// AWS Bedrock embeddings backend. It does not convert to or from the Amazon
+// Titan Embeddings InvokeModel shape: RequestBody and ResponseBody return
+// errEmbeddingsAWSBedrockNotImplemented.
+func NewEmbeddingsOpenAIToAWSBedrockTranslator() OpenAIEmbeddingsTranslator {
+	return &embeddingsOpenAIToAWSBedrockTranslator{}
+}
+
+type embeddingsOpenAIToAWSBedrockTranslator struct{}
+
+// RequestBody implements [OpenAIEmbeddingsTranslator.RequestBody].
+func (e *embeddingsOpenAIToAWSBedrockTranslator) RequestBody(_ *openai.EmbeddingRequest) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, error) {
+	// TODO: translate to the Titan Embeddings InvokeModel request shape.
+	return nil, nil, errEmbeddingsAWSBedrockNotImplemented
+}
+
+// ResponseHeaders implements [OpenAIEmbeddingsTranslator.ResponseHeaders].
+func (e *embeddingsOpenAIToAWSBedrockTranslator) ResponseHeaders(_ map[string]string) (*extprocv3.HeaderMutation, error) {
+	return nil, nil
+}
+
+// ResponseBody implements [OpenAIEmbeddingsTranslator.ResponseBody].
+func (e *embeddingsOpenAIToAWSBedrockTranslator) ResponseBody(_ map[string]string, _ io.Reader, _ bool) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, uint32, uint32, error) {
+	// TODO: translate the Titan Embeddings InvokeModel response back to the
+	// OpenAI shape and extract the billed input token count.
+	return nil, nil, 0, 0, errEmbeddingsAWSBedrockNotImplemented
+}
@@ -0,0 +1,51 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"sync"
+
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// cl100kEncoding is the BPE tokenizer tiktoken-go ships for the GPT-3.5/4
+// family (encoding name "cl100k_base"). Constructing it isn't free, and
+// estimateTokens can be called many times over the life of a single
+// streamed response, so it's built once lazily and cached rather than per
+// call.
+var (
+	cl100kOnce     sync.Once
+	cl100kEncoding *tiktoken.Tiktoken
+)
+
+// estimateTokens approximates the number of LLM tokens in s using the
+// cl100k_base BPE tokenizer. It is used as a fallback for streaming chunks
+// that carry no usage counts at all, e.g. per-chunk OpenAI deltas sent
+// without stream_options.include_usage. cl100k_base is the tokenizer used
+// by the GPT-3.5/4 family; for backends whose own tokenizer differs, this is
+// still an approximation, but a much closer one than a fixed
+// characters-per-token ratio. Callers that need exact counts should prefer
+// a chunk's reported usage object when one is present and only fall back to
+// this for chunks that omit it.
+func estimateTokens(s string) uint32 {
+	if s == "" {
+		return 0
+	}
+	cl100kOnce.Do(func() {
+		enc, err := tiktoken.GetEncoding("cl100k_base")
+		if err == nil {
+			cl100kEncoding = enc
+		}
+	})
+	if cl100kEncoding == nil {
+		// cl100k_base failed to load; fall back to a rough
+		// characters-per-token approximation rather than letting a usage
+		// estimate fail the request.
+		const averageCharsPerToken = 4
+		return uint32((len(s) + averageCharsPerToken - 1) / averageCharsPerToken) //nolint:gosec
+	}
+	return uint32(len(cl100kEncoding.Encode(s, nil, nil))) //nolint:gosec
+}
@@ -0,0 +1,64 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"io"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+)
+
+// OpenAIImagesTranslator translates an OpenAI-shaped image generation
+// request to a backend-native request, and the backend-native response back
+// to the OpenAI shape.
+type OpenAIImagesTranslator interface {
+	// RequestBody translates an OpenAI ImageGenerationRequest into the
+	// corresponding header and body mutations to apply to the outgoing
+	// request.
+	RequestBody(body *openai.ImageGenerationRequest) (headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation, err error)
+	// ResponseHeaders translates the backend's response headers into the
+	// header mutation to apply before forwarding to the client.
+	ResponseHeaders(headers map[string]string) (headerMutation *extprocv3.HeaderMutation, err error)
+	// ResponseBody translates the backend's response body into the OpenAI
+	// shape, returning the number of images generated.
+	ResponseBody(responseHeaders map[string]string, body io.Reader, endOfStream bool) (headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation, imageCount uint32, err error)
+}
+
+// NewImagesOpenAIToOpenAITranslator returns a translator that passes image
+// generation requests and responses through unmodified, extracting the
+// generated image count for metrics and cost accounting along the way.
+func NewImagesOpenAIToOpenAITranslator() OpenAIImagesTranslator {
+	return &imagesOpenAIToOpenAITranslator{}
+}
+
+type imagesOpenAIToOpenAITranslator struct{}
+
+// RequestBody implements [OpenAIImagesTranslator.RequestBody].
+func (i *imagesOpenAIToOpenAITranslator) RequestBody(_ *openai.ImageGenerationRequest) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, error) {
+	return nil, nil, nil
+}
+
+// ResponseHeaders implements [OpenAIImagesTranslator.ResponseHeaders].
+func (i *imagesOpenAIToOpenAITranslator) ResponseHeaders(_ map[string]string) (*extprocv3.HeaderMutation, error) {
+	return nil, nil
+}
+
+// imageGenerationResponse is the minimal shape needed to count the images
+// returned by an OpenAI "Create image" response.
+type imageGenerationResponse struct {
+	Data []interface{} `json:"data"`
+}
+
+// ResponseBody implements [OpenAIImagesTranslator.ResponseBody].
+func (i *imagesOpenAIToOpenAITranslator) ResponseBody(_ map[string]string, body io.Reader, _ bool) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, uint32, error) {
+	var resp imageGenerationResponse
+	if err := decodeJSON(body, &resp); err != nil {
+		return nil, nil, 0, err
+	}
+	return nil, nil, uint32(len(resp.Data)), nil
+}
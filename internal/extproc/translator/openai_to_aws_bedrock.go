@@ -0,0 +1,53 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"io"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+)
+
+// errChatCompletionAWSBedrockNotImplemented is returned by every method of
+// openAIToAWSBedrockTranslator until the Bedrock Converse request/response
+// translation is implemented. Returning it, rather than silently passing
+// the request through untranslated and reporting a zero LLMTokenUsage,
+// means a Bedrock-routed chat completion fails loudly instead of serving
+// broken responses with fabricated (zero) cost/usage forever.
+var errChatCompletionAWSBedrockNotImplemented = errors.New("AWS Bedrock chat completion translation is not implemented")
+
+// NewChatCompletionOpenAIToAWSBedrockTranslator returns the translator for an
+// AWS Bedrock chat completion backend. It does not convert to or from the
+// Bedrock Converse API shape: RequestBody and ResponseBody return
+// errChatCompletionAWSBedrockNotImplemented.
+func NewChatCompletionOpenAIToAWSBedrockTranslator() OpenAIChatCompletionTranslator {
+	return &openAIToAWSBedrockTranslator{}
+}
+
+type openAIToAWSBedrockTranslator struct{}
+
+// RequestBody implements [OpenAIChatCompletionTranslator.RequestBody].
+func (o *openAIToAWSBedrockTranslator) RequestBody(_ *openai.ChatCompletionRequest) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, error) {
+	// TODO: translate to the Bedrock Converse request shape.
+	return nil, nil, errChatCompletionAWSBedrockNotImplemented
+}
+
+// ResponseHeaders implements [OpenAIChatCompletionTranslator.ResponseHeaders].
+func (o *openAIToAWSBedrockTranslator) ResponseHeaders(_ map[string]string) (*extprocv3.HeaderMutation, error) {
+	return nil, nil
+}
+
+// ResponseBody implements [OpenAIChatCompletionTranslator.ResponseBody].
+func (o *openAIToAWSBedrockTranslator) ResponseBody(_ map[string]string, _ io.Reader, _ bool) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, LLMTokenUsage, error) {
+	// TODO: translate the Bedrock Converse response shape back to OpenAI,
+	// extracting exact usage when the final event reports it and falling
+	// back to estimateTokens for provider-specific streams (e.g. Anthropic
+	// on Bedrock) that only report usage in their final SSE event.
+	return nil, nil, LLMTokenUsage{}, errChatCompletionAWSBedrockNotImplemented
+}
@@ -0,0 +1,83 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+
+	"github.com/envoyproxy/ai-gateway/filterapi"
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+)
+
+// NewChatCompletionOpenAIToAzureOpenAITranslator returns a translator that
+// forwards OpenAI chat completion requests to the Azure OpenAI deployment
+// mapped to the request's model in deployments, requesting apiVersionAlias
+// of that deployment.
+func NewChatCompletionOpenAIToAzureOpenAITranslator(apiVersionAlias string, deployments map[string]filterapi.AzureOpenAIDeployment) OpenAIChatCompletionTranslator {
+	return &openAIToAzureOpenAITranslator{apiVersionAlias: apiVersionAlias, deployments: deployments}
+}
+
+type openAIToAzureOpenAITranslator struct {
+	apiVersionAlias string
+	deployments     map[string]filterapi.AzureOpenAIDeployment
+	// passthrough extracts token usage from the Azure OpenAI response, which
+	// is already OpenAI-shaped.
+	passthrough openAIToOpenAITranslator
+}
+
+// RequestBody implements [OpenAIChatCompletionTranslator.RequestBody]. Azure
+// OpenAI addresses models by deployment name in the request path rather than
+// by the "model" field in the body, so this rewrites :path to the
+// deployment-scoped endpoint with the resolved api-version query parameter
+// and strips "model" from the forwarded body.
+func (o *openAIToAzureOpenAITranslator) RequestBody(body *openai.ChatCompletionRequest) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, error) {
+	deployment, ok := o.deployments[body.Model]
+	if !ok {
+		return nil, nil, fmt.Errorf("no Azure OpenAI deployment configured for model %q", body.Model)
+	}
+
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, nil, fmt.Errorf("failed to re-decode request body: %w", err)
+	}
+	delete(fields, "model")
+	stripped, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal stripped request body: %w", err)
+	}
+
+	path := fmt.Sprintf("/openai/deployments/%s/chat/completions?api-version=%s",
+		url.PathEscape(deployment.ID), url.QueryEscape(deployment.ResolveAPIVersion(o.apiVersionAlias)))
+	headerMutation := &extprocv3.HeaderMutation{
+		SetHeaders: []*corev3.HeaderValueOption{
+			{Header: &corev3.HeaderValue{Key: ":path", RawValue: []byte(path)}},
+		},
+	}
+	bodyMutation := &extprocv3.BodyMutation{Mutation: &extprocv3.BodyMutation_Body{Body: stripped}}
+	return headerMutation, bodyMutation, nil
+}
+
+// ResponseHeaders implements [OpenAIChatCompletionTranslator.ResponseHeaders].
+func (o *openAIToAzureOpenAITranslator) ResponseHeaders(headers map[string]string) (*extprocv3.HeaderMutation, error) {
+	return o.passthrough.ResponseHeaders(headers)
+}
+
+// ResponseBody implements [OpenAIChatCompletionTranslator.ResponseBody].
+// Azure OpenAI responses are already OpenAI-shaped, so usage extraction is
+// delegated to the same logic used for an OpenAI backend.
+func (o *openAIToAzureOpenAITranslator) ResponseBody(responseHeaders map[string]string, body io.Reader, endOfStream bool) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, LLMTokenUsage, error) {
+	return o.passthrough.ResponseBody(responseHeaders, body, endOfStream)
+}
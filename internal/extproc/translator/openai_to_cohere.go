@@ -0,0 +1,360 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+)
+
+// cohereChatPath is the path of the Cohere Chat API that every request is
+// translated to, regardless of the path the client used.
+const cohereChatPath = "/v1/chat"
+
+// NewChatCompletionOpenAIToCohereTranslator returns a translator that
+// converts OpenAI chat completion requests to the Cohere Chat API shape, and
+// Cohere responses, including streamed ones, back to the OpenAI shape.
+func NewChatCompletionOpenAIToCohereTranslator() OpenAIChatCompletionTranslator {
+	return &openAIToCohereTranslator{}
+}
+
+type openAIToCohereTranslator struct {
+	stream bool
+}
+
+// cohereChatRequest is the request body of the Cohere "Chat" API.
+//
+// https://docs.cohere.com/reference/chat
+type cohereChatRequest struct {
+	Message     string              `json:"message"`
+	ChatHistory []cohereChatMessage `json:"chat_history,omitempty"`
+	Model       string              `json:"model,omitempty"`
+	Stream      bool                `json:"stream,omitempty"`
+	Temperature *float64            `json:"temperature,omitempty"`
+	Tools       []cohereTool        `json:"tools,omitempty"`
+}
+
+// cohereChatMessage is a single turn in ChatHistory.
+type cohereChatMessage struct {
+	Role    string `json:"role"`
+	Message string `json:"message"`
+}
+
+// cohereTool is a tool definition in the Cohere Chat API shape.
+type cohereTool struct {
+	Name                 string                 `json:"name"`
+	Description          string                 `json:"description,omitempty"`
+	ParameterDefinitions map[string]interface{} `json:"parameter_definitions,omitempty"`
+}
+
+// cohereToolCall is a single tool call emitted by the model, in the Cohere
+// Chat API shape.
+type cohereToolCall struct {
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters"`
+}
+
+const (
+	cohereRoleUser    = "USER"
+	cohereRoleChatbot = "CHATBOT"
+	cohereRoleSystem  = "SYSTEM"
+)
+
+func cohereRoleFromOpenAI(role string) string {
+	switch role {
+	case "assistant":
+		return cohereRoleChatbot
+	case "system":
+		return cohereRoleSystem
+	default:
+		return cohereRoleUser
+	}
+}
+
+// RequestBody implements [OpenAIChatCompletionTranslator.RequestBody].
+func (o *openAIToCohereTranslator) RequestBody(body *openai.ChatCompletionRequest) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, error) {
+	o.stream = body.Stream
+
+	req := cohereChatRequest{
+		Model:       body.Model,
+		Stream:      body.Stream,
+		Temperature: body.Temperature,
+	}
+	for i, m := range body.Messages {
+		// The last message becomes the standalone "message" field; everything
+		// before it becomes chat_history, matching the Cohere Chat API shape.
+		if i == len(body.Messages)-1 {
+			req.Message = m.Content
+			continue
+		}
+		req.ChatHistory = append(req.ChatHistory, cohereChatMessage{
+			Role:    cohereRoleFromOpenAI(m.Role),
+			Message: m.Content,
+		})
+	}
+	for _, t := range body.Tools {
+		req.Tools = append(req.Tools, cohereTool{
+			Name:                 t.Function.Name,
+			Description:          t.Function.Description,
+			ParameterDefinitions: t.Function.Parameters,
+		})
+	}
+
+	mutatedBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal Cohere chat request: %w", err)
+	}
+
+	headerMutation := &extprocv3.HeaderMutation{
+		SetHeaders: []*corev3.HeaderValueOption{
+			{Header: &corev3.HeaderValue{Key: ":path", RawValue: []byte(cohereChatPath)}},
+		},
+	}
+	return headerMutation, &extprocv3.BodyMutation{
+		Mutation: &extprocv3.BodyMutation_Body{Body: mutatedBody},
+	}, nil
+}
+
+// ResponseHeaders implements [OpenAIChatCompletionTranslator.ResponseHeaders].
+func (o *openAIToCohereTranslator) ResponseHeaders(_ map[string]string) (*extprocv3.HeaderMutation, error) {
+	return nil, nil
+}
+
+// cohereBilledUnits is the token accounting block shared by both the
+// streaming "stream-end" event and the non-streaming chat response.
+type cohereBilledUnits struct {
+	InputTokens  float64 `json:"input_tokens"`
+	OutputTokens float64 `json:"output_tokens"`
+}
+
+// cohereStreamEvent is the envelope of every newline-delimited JSON event in
+// a Cohere streamed chat response.
+//
+// https://docs.cohere.com/reference/chat#streaming
+type cohereStreamEvent struct {
+	EventType string           `json:"event_type"`
+	Text      string           `json:"text,omitempty"`
+	ToolCalls []cohereToolCall `json:"tool_calls,omitempty"`
+	Response  *struct {
+		Meta struct {
+			BilledUnits cohereBilledUnits `json:"billed_units"`
+		} `json:"meta"`
+	} `json:"response,omitempty"`
+}
+
+// cohereChatResponse is the body of a non-streaming Cohere Chat API
+// response: a single JSON object, not a stream event.
+//
+// https://docs.cohere.com/reference/chat
+type cohereChatResponse struct {
+	Text         string           `json:"text"`
+	ToolCalls    []cohereToolCall `json:"tool_calls,omitempty"`
+	FinishReason string           `json:"finish_reason,omitempty"`
+	Meta         struct {
+		BilledUnits cohereBilledUnits `json:"billed_units"`
+	} `json:"meta"`
+}
+
+// ResponseBody implements [OpenAIChatCompletionTranslator.ResponseBody].
+//
+// Non-streaming Cohere responses are a single JSON object with no
+// "event_type" field, while streaming responses are newline-delimited JSON
+// events; the two are unmarshaled into distinct shapes and translated
+// separately.
+func (o *openAIToCohereTranslator) ResponseBody(_ map[string]string, body io.Reader, _ bool) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, LLMTokenUsage, error) {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, LLMTokenUsage{}, fmt.Errorf("failed to read Cohere response body: %w", err)
+	}
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return nil, nil, LLMTokenUsage{}, nil
+	}
+	if !o.stream {
+		bodyMutation, usage, err := o.translateNonStreamingResponse(trimmed)
+		if err != nil {
+			return nil, nil, LLMTokenUsage{}, err
+		}
+		return nil, bodyMutation, usage, nil
+	}
+	return o.translateStreamingChunk(trimmed)
+}
+
+// translateNonStreamingResponse decodes a complete non-streaming Cohere chat
+// response and builds the equivalent OpenAI "chat.completion" JSON object.
+func (o *openAIToCohereTranslator) translateNonStreamingResponse(raw []byte) (*extprocv3.BodyMutation, LLMTokenUsage, error) {
+	var resp cohereChatResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, LLMTokenUsage{}, fmt.Errorf("failed to unmarshal Cohere chat response: %w", err)
+	}
+	toolCalls, err := toOpenAIToolCalls(resp.ToolCalls)
+	if err != nil {
+		return nil, LLMTokenUsage{}, err
+	}
+	message := map[string]interface{}{"role": "assistant", "content": resp.Text}
+	if len(toolCalls) > 0 {
+		message["tool_calls"] = toolCalls
+	}
+	usage := tokenUsageFromBilledUnits(resp.Meta.BilledUnits)
+	openAIResp := map[string]interface{}{
+		"object": "chat.completion",
+		"choices": []map[string]interface{}{
+			{"index": 0, "message": message, "finish_reason": openAIFinishReason(resp.FinishReason)},
+		},
+		"usage": map[string]interface{}{
+			"prompt_tokens":     usage.InputTokens,
+			"completion_tokens": usage.OutputTokens,
+			"total_tokens":      usage.TotalTokens,
+		},
+	}
+	b, err := json.Marshal(openAIResp)
+	if err != nil {
+		return nil, LLMTokenUsage{}, fmt.Errorf("failed to marshal OpenAI chat completion response: %w", err)
+	}
+	return &extprocv3.BodyMutation{Mutation: &extprocv3.BodyMutation_Body{Body: b}}, usage, nil
+}
+
+// translateStreamingChunk decodes a chunk of newline-delimited Cohere stream
+// events and translates each into an OpenAI "chat.completion.chunk" SSE
+// event.
+func (o *openAIToCohereTranslator) translateStreamingChunk(raw []byte) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, LLMTokenUsage, error) {
+	var usage LLMTokenUsage
+	var sse []byte
+
+	scanner := bufio.NewScanner(bytes.NewReader(raw))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var ev cohereStreamEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			return nil, nil, LLMTokenUsage{}, fmt.Errorf("failed to unmarshal Cohere stream event: %w", err)
+		}
+		switch ev.EventType {
+		case "stream-start":
+			sse = append(sse, sseChatCompletionChunk(chatCompletionChunkDelta{Role: "assistant"})...)
+		case "text-generation":
+			sse = append(sse, sseChatCompletionChunk(chatCompletionChunkDelta{Content: ev.Text})...)
+		case "tool-calls-generation":
+			toolCalls, err := toOpenAIToolCalls(ev.ToolCalls)
+			if err != nil {
+				return nil, nil, LLMTokenUsage{}, err
+			}
+			sse = append(sse, sseChatCompletionChunk(chatCompletionChunkDelta{ToolCalls: toolCalls})...)
+		case "stream-end":
+			if ev.Response != nil {
+				usage = tokenUsageFromBilledUnits(ev.Response.Meta.BilledUnits)
+			}
+			sse = append(sse, []byte("data: [DONE]\n\n")...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, LLMTokenUsage{}, fmt.Errorf("failed to read Cohere response stream: %w", err)
+	}
+
+	if len(sse) == 0 {
+		return nil, nil, usage, nil
+	}
+	return nil, &extprocv3.BodyMutation{Mutation: &extprocv3.BodyMutation_Body{Body: sse}}, usage, nil
+}
+
+// tokenUsageFromBilledUnits converts Cohere's billed_units block into an
+// LLMTokenUsage.
+func tokenUsageFromBilledUnits(u cohereBilledUnits) LLMTokenUsage {
+	usage := LLMTokenUsage{
+		InputTokens:  uint32(u.InputTokens),
+		OutputTokens: uint32(u.OutputTokens),
+	}
+	usage.TotalTokens = usage.InputTokens + usage.OutputTokens
+	return usage
+}
+
+// openAIFinishReason maps a Cohere finish_reason to the closest OpenAI
+// equivalent.
+func openAIFinishReason(cohereReason string) string {
+	switch cohereReason {
+	case "MAX_TOKENS":
+		return "length"
+	case "ERROR_TOXIC", "ERROR_LIMIT", "ERROR":
+		return "content_filter"
+	default:
+		return "stop"
+	}
+}
+
+// openAIToolCall is a single tool call in the OpenAI chat completion shape.
+type openAIToolCall struct {
+	ID       string             `json:"id"`
+	Type     string             `json:"type"`
+	Function openAIToolFunction `json:"function"`
+}
+
+// openAIToolFunction is the "function" field of an openAIToolCall.
+type openAIToolFunction struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// toOpenAIToolCalls converts Cohere tool calls into the OpenAI tool_calls
+// shape: each call gets a stable per-response ID and type "function", and
+// Parameters is marshaled into Arguments as a JSON string rather than sent
+// as a nested object, matching what OpenAI clients expect to parse.
+func toOpenAIToolCalls(calls []cohereToolCall) ([]openAIToolCall, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+	out := make([]openAIToolCall, len(calls))
+	for i, c := range calls {
+		args, err := json.Marshal(c.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tool call parameters: %w", err)
+		}
+		out[i] = openAIToolCall{
+			ID:   fmt.Sprintf("call_%d", i),
+			Type: "function",
+			Function: openAIToolFunction{
+				Name:      c.Name,
+				Arguments: string(args),
+			},
+		}
+	}
+	return out, nil
+}
+
+// chatCompletionChunkDelta is the "delta" field of an OpenAI
+// chat.completion.chunk streamed choice.
+type chatCompletionChunkDelta struct {
+	Role      string           `json:"role,omitempty"`
+	Content   string           `json:"content,omitempty"`
+	ToolCalls []openAIToolCall `json:"tool_calls,omitempty"`
+}
+
+// sseChatCompletionChunk wraps delta in an OpenAI-shaped chat.completion.chunk
+// object and formats it as a single server-sent event.
+func sseChatCompletionChunk(delta chatCompletionChunkDelta) []byte {
+	chunk := map[string]interface{}{
+		"object": "chat.completion.chunk",
+		"choices": []map[string]interface{}{
+			{"index": 0, "delta": delta},
+		},
+	}
+	b, err := json.Marshal(chunk)
+	if err != nil {
+		// Marshaling a map of static types never fails.
+		panic(err)
+	}
+	return append(append([]byte("data: "), b...), []byte("\n\n")...)
+}
@@ -0,0 +1,130 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+)
+
+func TestOpenAIToCohereTranslator_ResponseBody_NonStreaming(t *testing.T) {
+	tr := &openAIToCohereTranslator{}
+	if _, _, err := tr.RequestBody(&openai.ChatCompletionRequest{Model: "command-r"}); err != nil {
+		t.Fatalf("RequestBody: %v", err)
+	}
+
+	cohereResp := `{
+		"text": "hello there",
+		"finish_reason": "COMPLETE",
+		"meta": {"billed_units": {"input_tokens": 3, "output_tokens": 2}}
+	}`
+	_, bodyMutation, usage, err := tr.ResponseBody(nil, strings.NewReader(cohereResp), true)
+	if err != nil {
+		t.Fatalf("ResponseBody: %v", err)
+	}
+	if usage.InputTokens != 3 || usage.OutputTokens != 2 || usage.TotalTokens != 5 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+	if bodyMutation == nil {
+		t.Fatal("expected a body mutation")
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(bodyMutation.GetBody(), &got); err != nil {
+		t.Fatalf("translated body isn't valid JSON: %v", err)
+	}
+	if got["object"] != "chat.completion" {
+		t.Fatalf("expected object=chat.completion, got %v", got["object"])
+	}
+	choices, _ := got["choices"].([]interface{})
+	if len(choices) != 1 {
+		t.Fatalf("expected 1 choice, got %d", len(choices))
+	}
+	message := choices[0].(map[string]interface{})["message"].(map[string]interface{})
+	if message["content"] != "hello there" || message["role"] != "assistant" {
+		t.Fatalf("unexpected message: %+v", message)
+	}
+}
+
+func TestOpenAIToCohereTranslator_ResponseBody_NonStreaming_ToolCalls(t *testing.T) {
+	tr := &openAIToCohereTranslator{}
+	cohereResp := `{
+		"text": "",
+		"tool_calls": [{"name": "get_weather", "parameters": {"city": "nyc"}}],
+		"meta": {"billed_units": {"input_tokens": 1, "output_tokens": 1}}
+	}`
+	_, bodyMutation, _, err := tr.ResponseBody(nil, strings.NewReader(cohereResp), true)
+	if err != nil {
+		t.Fatalf("ResponseBody: %v", err)
+	}
+	var got struct {
+		Choices []struct {
+			Message struct {
+				ToolCalls []struct {
+					ID       string `json:"id"`
+					Type     string `json:"type"`
+					Function struct {
+						Name      string `json:"name"`
+						Arguments string `json:"arguments"`
+					} `json:"function"`
+				} `json:"tool_calls"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal(bodyMutation.GetBody(), &got); err != nil {
+		t.Fatalf("translated body isn't valid JSON: %v", err)
+	}
+	toolCalls := got.Choices[0].Message.ToolCalls
+	if len(toolCalls) != 1 {
+		t.Fatalf("expected 1 tool call, got %d", len(toolCalls))
+	}
+	tc := toolCalls[0]
+	if tc.Type != "function" || tc.Function.Name != "get_weather" {
+		t.Fatalf("unexpected tool call: %+v", tc)
+	}
+	var args map[string]interface{}
+	if err := json.Unmarshal([]byte(tc.Function.Arguments), &args); err != nil {
+		t.Fatalf("Arguments isn't a JSON string: %v", err)
+	}
+	if args["city"] != "nyc" {
+		t.Fatalf("unexpected arguments: %+v", args)
+	}
+}
+
+func TestOpenAIToCohereTranslator_ResponseBody_Streaming(t *testing.T) {
+	tr := &openAIToCohereTranslator{stream: true}
+	events := []string{
+		`{"event_type":"stream-start"}`,
+		`{"event_type":"text-generation","text":"hi"}`,
+		`{"event_type":"stream-end","response":{"meta":{"billed_units":{"input_tokens":4,"output_tokens":6}}}}`,
+	}
+	raw := bytes.Join(toLines(events), []byte("\n"))
+	_, bodyMutation, usage, err := tr.ResponseBody(nil, bytes.NewReader(raw), true)
+	if err != nil {
+		t.Fatalf("ResponseBody: %v", err)
+	}
+	if usage.InputTokens != 4 || usage.OutputTokens != 6 || usage.TotalTokens != 10 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+	sse := string(bodyMutation.GetBody())
+	if !strings.Contains(sse, `"content":"hi"`) {
+		t.Fatalf("expected streamed content in SSE output, got %q", sse)
+	}
+	if !strings.Contains(sse, "data: [DONE]") {
+		t.Fatalf("expected a terminal [DONE] event, got %q", sse)
+	}
+}
+
+func toLines(lines []string) [][]byte {
+	out := make([][]byte, len(lines))
+	for i, l := range lines {
+		out[i] = []byte(l)
+	}
+	return out
+}
@@ -0,0 +1,170 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+)
+
+// NewChatCompletionOpenAIToOpenAITranslator returns a translator that passes
+// requests and responses through unmodified, since both sides already speak
+// the OpenAI schema. It still parses the response to extract token usage.
+func NewChatCompletionOpenAIToOpenAITranslator() OpenAIChatCompletionTranslator {
+	return &openAIToOpenAITranslator{}
+}
+
+// openAIToOpenAITranslator tracks running token usage across the calls that
+// make up a single streamed response, so that ResponseBody can report each
+// call's usage as an increment over what it has already reported rather than
+// double-counting once an authoritative usage object arrives. See
+// parseStreamingChunk.
+type openAIToOpenAITranslator struct {
+	// reported is the cumulative usage already returned to the caller for
+	// this stream.
+	reported LLMTokenUsage
+	// sawAuthoritativeUsage is true once a chunk has reported a usage
+	// object, after which heuristic estimation stops.
+	sawAuthoritativeUsage bool
+}
+
+// RequestBody implements [OpenAIChatCompletionTranslator.RequestBody].
+func (o *openAIToOpenAITranslator) RequestBody(_ *openai.ChatCompletionRequest) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, error) {
+	// No translation needed; the request is forwarded as-is.
+	return nil, nil, nil
+}
+
+// ResponseHeaders implements [OpenAIChatCompletionTranslator.ResponseHeaders].
+func (o *openAIToOpenAITranslator) ResponseHeaders(_ map[string]string) (*extprocv3.HeaderMutation, error) {
+	return nil, nil
+}
+
+// chatCompletionResponseUsage is the minimal shape needed to extract token
+// usage from a non-streaming OpenAI chat completion response.
+type chatCompletionResponseUsage struct {
+	Usage struct {
+		PromptTokens     uint32 `json:"prompt_tokens"`
+		CompletionTokens uint32 `json:"completion_tokens"`
+		TotalTokens      uint32 `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// ResponseBody implements [OpenAIChatCompletionTranslator.ResponseBody].
+func (o *openAIToOpenAITranslator) ResponseBody(_ map[string]string, body io.Reader, _ bool) (*extprocv3.HeaderMutation, *extprocv3.BodyMutation, LLMTokenUsage, error) {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, LLMTokenUsage{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return nil, nil, LLMTokenUsage{}, nil
+	}
+	if trimmed[0] != '{' {
+		// Not a single JSON object, so this is a chunk of an SSE stream.
+		return nil, nil, o.parseStreamingChunk(trimmed), nil
+	}
+	var resp chatCompletionResponseUsage
+	if err := json.Unmarshal(trimmed, &resp); err != nil {
+		return nil, nil, LLMTokenUsage{}, fmt.Errorf("failed to decode response body: %w", err)
+	}
+	return nil, nil, LLMTokenUsage{
+		InputTokens:  resp.Usage.PromptTokens,
+		OutputTokens: resp.Usage.CompletionTokens,
+		TotalTokens:  resp.Usage.TotalTokens,
+	}, nil
+}
+
+// chatCompletionStreamChunk is the minimal shape needed to extract token
+// usage, or failing that the streamed content to estimate it from, out of a
+// single "data: {...}" event of an OpenAI chat completion SSE stream.
+type chatCompletionStreamChunk struct {
+	Usage *struct {
+		PromptTokens     uint32 `json:"prompt_tokens"`
+		CompletionTokens uint32 `json:"completion_tokens"`
+		TotalTokens      uint32 `json:"total_tokens"`
+	} `json:"usage"`
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+// parseStreamingChunk extracts token usage from a chunk of an OpenAI chat
+// completion SSE stream, which may contain zero or more "data: " events, and
+// returns the increment over what this translator has already reported for
+// the stream so far.
+//
+// A usage object is only sent when the client set
+// stream_options.include_usage, in a final event with empty choices that
+// reports the cumulative usage for the whole response; every earlier event
+// carries usage: null and is estimated heuristically from its delta content
+// instead, since OpenAI doesn't report per-chunk counts. Once the
+// authoritative usage arrives it replaces, rather than adds to, the
+// heuristic totals accumulated so far, so the caller's own running total
+// isn't inflated by summing both. If the heuristic total already exceeds
+// the authoritative one, the increment is zero and the caller's total stays
+// at the heuristic value.
+func (o *openAIToOpenAITranslator) parseStreamingChunk(raw []byte) LLMTokenUsage {
+	var delta LLMTokenUsage
+	for _, line := range bytes.Split(raw, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		line = bytes.TrimSpace(bytes.TrimPrefix(line, []byte("data:")))
+		if len(line) == 0 || bytes.Equal(line, []byte("[DONE]")) {
+			continue
+		}
+		var chunk chatCompletionStreamChunk
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			continue
+		}
+		if chunk.Usage != nil {
+			authoritative := LLMTokenUsage{
+				InputTokens:  chunk.Usage.PromptTokens,
+				OutputTokens: chunk.Usage.CompletionTokens,
+				TotalTokens:  chunk.Usage.TotalTokens,
+			}
+			delta.InputTokens += saturatingSub(authoritative.InputTokens, o.reported.InputTokens)
+			delta.OutputTokens += saturatingSub(authoritative.OutputTokens, o.reported.OutputTokens)
+			delta.TotalTokens += saturatingSub(authoritative.TotalTokens, o.reported.TotalTokens)
+			o.reported = authoritative
+			o.sawAuthoritativeUsage = true
+			continue
+		}
+		if o.sawAuthoritativeUsage {
+			continue
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content == "" {
+				continue
+			}
+			estimated := estimateTokens(choice.Delta.Content)
+			delta.OutputTokens += estimated
+			delta.TotalTokens += estimated
+			o.reported.OutputTokens += estimated
+			o.reported.TotalTokens += estimated
+		}
+	}
+	return delta
+}
+
+// saturatingSub returns a-b, or 0 if b > a, to avoid wrapping a uint32
+// subtraction when the authoritative total undercuts the heuristic estimate
+// it's replacing.
+func saturatingSub(a, b uint32) uint32 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
@@ -0,0 +1,58 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIToOpenAITranslator_ResponseBody_NonStreaming(t *testing.T) {
+	tr := &openAIToOpenAITranslator{}
+	resp := `{"choices":[{"message":{"role":"assistant","content":"hi"}}],"usage":{"prompt_tokens":3,"completion_tokens":2,"total_tokens":5}}`
+	_, _, usage, err := tr.ResponseBody(nil, strings.NewReader(resp), true)
+	if err != nil {
+		t.Fatalf("ResponseBody: %v", err)
+	}
+	if usage.InputTokens != 3 || usage.OutputTokens != 2 || usage.TotalTokens != 5 {
+		t.Fatalf("unexpected usage: %+v", usage)
+	}
+}
+
+// TestOpenAIToOpenAITranslator_ResponseBody_Streaming_AuthoritativeUsageReplacesHeuristic
+// reproduces a stream_options.include_usage stream: several content chunks
+// with usage: null, heuristically estimated, followed by one final chunk
+// with the authoritative cumulative usage for the whole response. Summing
+// every call's returned usage must equal the authoritative total, not the
+// authoritative total plus the heuristic estimates that preceded it.
+func TestOpenAIToOpenAITranslator_ResponseBody_Streaming_AuthoritativeUsageReplacesHeuristic(t *testing.T) {
+	tr := &openAIToOpenAITranslator{}
+
+	chunk1 := `data: {"choices":[{"delta":{"content":"hello there"}}]}` + "\n\n"
+	_, _, delta1, err := tr.ResponseBody(nil, bytes.NewReader([]byte(chunk1)), false)
+	if err != nil {
+		t.Fatalf("ResponseBody (chunk1): %v", err)
+	}
+	if delta1.OutputTokens == 0 {
+		t.Fatalf("expected a non-zero heuristic estimate, got %+v", delta1)
+	}
+
+	chunk2 := `data: {"choices":[],"usage":{"prompt_tokens":10,"completion_tokens":20,"total_tokens":30}}` + "\n\ndata: [DONE]\n\n"
+	_, _, delta2, err := tr.ResponseBody(nil, bytes.NewReader([]byte(chunk2)), true)
+	if err != nil {
+		t.Fatalf("ResponseBody (chunk2): %v", err)
+	}
+
+	total := LLMTokenUsage{
+		InputTokens:  delta1.InputTokens + delta2.InputTokens,
+		OutputTokens: delta1.OutputTokens + delta2.OutputTokens,
+		TotalTokens:  delta1.TotalTokens + delta2.TotalTokens,
+	}
+	if total.InputTokens != 10 || total.OutputTokens != 20 || total.TotalTokens != 30 {
+		t.Fatalf("accumulated usage diverged from the authoritative total: %+v", total)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package translator converts between the OpenAI-compatible API surface that
+// the AI Gateway exposes to clients and the native request/response shapes
+// of each supported backend.
+package translator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/service/ext_proc/v3"
+
+	"github.com/envoyproxy/ai-gateway/internal/apischema/openai"
+)
+
+// decodeJSON decodes a single JSON value from r into v, wrapping any error
+// with context. It is shared by the translators for the non-streaming
+// response shapes (embeddings, images) that never span multiple
+// response body chunks.
+func decodeJSON(r io.Reader, v interface{}) error {
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
+	}
+	return nil
+}
+
+// LLMTokenUsage holds the token accounting for a single request/response
+// pair, as reported by the backend or estimated by the translator.
+type LLMTokenUsage struct {
+	// InputTokens is the number of tokens in the prompt.
+	InputTokens uint32
+	// OutputTokens is the number of tokens generated by the model.
+	OutputTokens uint32
+	// TotalTokens is InputTokens plus OutputTokens.
+	TotalTokens uint32
+}
+
+// OpenAIChatCompletionTranslator translates an OpenAI-shaped chat completion
+// request to a backend-native request, and the backend-native response back
+// to the OpenAI shape.
+type OpenAIChatCompletionTranslator interface {
+	// RequestBody translates an OpenAI ChatCompletionRequest into the
+	// corresponding header and body mutations to apply to the outgoing
+	// request.
+	RequestBody(body *openai.ChatCompletionRequest) (headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation, err error)
+	// ResponseHeaders translates the backend's response headers into the
+	// header mutation to apply before forwarding to the client.
+	ResponseHeaders(headers map[string]string) (headerMutation *extprocv3.HeaderMutation, err error)
+	// ResponseBody translates a chunk of the backend's response body,
+	// returning the mutations to apply and the token usage this chunk adds
+	// to what earlier calls for the same response have already returned.
+	// endOfStream indicates this is the final chunk of the response.
+	ResponseBody(responseHeaders map[string]string, body io.Reader, endOfStream bool) (headerMutation *extprocv3.HeaderMutation, bodyMutation *extprocv3.BodyMutation, tokenUsage LLMTokenUsage, err error)
+}
@@ -0,0 +1,72 @@
+// Copyright Envoy AI Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+// Package llmcostcel compiles and evaluates the CEL expressions used to
+// compute custom request costs from token usage and routing metadata.
+package llmcostcel
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+// Program is a compiled CEL expression for an LLMRequestCostTypeCEL entry.
+// The [cel.Program] planning step is done once in NewProgram and reused by
+// every EvaluateProgram call, since planning is the expensive part of
+// evaluating a CEL expression and a Program is evaluated repeatedly over the
+// life of a request (e.g. every streamed chunk).
+type Program struct {
+	program cel.Program
+}
+
+// NewProgram compiles expr into a [Program]. The expression has access to
+// the "model", "backend", "input_tokens", "output_tokens", and "total_tokens"
+// variables and must evaluate to an integer.
+func NewProgram(expr string) (*Program, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("model", cel.StringType),
+		cel.Variable("backend", cel.StringType),
+		cel.Variable("input_tokens", cel.UintType),
+		cel.Variable("output_tokens", cel.UintType),
+		cel.Variable("total_tokens", cel.UintType),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	ast, iss := env.Compile(expr)
+	if iss != nil && iss.Err() != nil {
+		return nil, fmt.Errorf("failed to compile CEL expression %q: %w", expr, iss.Err())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct CEL program: %w", err)
+	}
+	return &Program{program: program}, nil
+}
+
+// EvaluateProgram evaluates prog against the given model, backend, and token
+// usage, returning the resulting cost.
+func EvaluateProgram(prog *Program, model, backend string, inputTokens, outputTokens, totalTokens uint32) (uint64, error) {
+	out, _, err := prog.program.Eval(map[string]interface{}{
+		"model":         model,
+		"backend":       backend,
+		"input_tokens":  uint64(inputTokens),
+		"output_tokens": uint64(outputTokens),
+		"total_tokens":  uint64(totalTokens),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to evaluate CEL expression: %w", err)
+	}
+	v, ok := out.Value().(uint64)
+	if !ok {
+		if iv, ok := out.(types.Int); ok {
+			return uint64(iv), nil
+		}
+		return 0, fmt.Errorf("CEL expression did not evaluate to an integer, got %T", out.Value())
+	}
+	return v, nil
+}